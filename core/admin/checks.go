@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Pinger is implemented by *sql.DB and *sqlx.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// PingCheck fails when db does not answer a ping.
+func PingCheck(name string, db Pinger) Check {
+	return Check{Name: name, Run: db.PingContext}
+}
+
+// FreshnessCheck fails when last reports a time older than maxAge. Until
+// last reports anything, the age is counted from the creation of the check.
+func FreshnessCheck(name string, last func() time.Time, maxAge time.Duration) Check {
+	created := time.Now()
+	return Check{Name: name, Run: func(context.Context) error {
+		at := last()
+		if at.IsZero() {
+			at = created
+		}
+		if age := time.Since(at); age > maxAge {
+			return fmt.Errorf("last activity %s ago (max %s)", age.Round(time.Second), maxAge)
+		}
+		return nil
+	}}
+}
+
+// SaturationCheck fails when depth reports a queue fuller than max (0..1).
+func SaturationCheck(name string, depth func() (used, capacity int), max float64) Check {
+	return Check{Name: name, Run: func(context.Context) error {
+		used, capacity := depth()
+		if capacity <= 0 {
+			return nil
+		}
+		if ratio := float64(used) / float64(capacity); ratio > max {
+			return fmt.Errorf("queue %d/%d exceeds %.0f%%", used, capacity, max*100)
+		}
+		return nil
+	}}
+}
@@ -0,0 +1,3 @@
+// Package admin provides the operational HTTP server: liveness (/healthz),
+// readiness (/readyz) built from pluggable checks, and build metadata (/version).
+package admin
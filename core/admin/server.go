@@ -0,0 +1,214 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/m3rciful/gobot/core/buildinfo"
+	"github.com/m3rciful/gobot/core/logger"
+)
+
+const defaultCheckTimeout = 2 * time.Second
+
+// Check is a named readiness probe; a nil error means healthy.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Options configures a Server.
+type Options struct {
+	// Addr is the listen address, for example ":9090".
+	Addr   string
+	Checks []Check
+	// CheckTimeout bounds each readiness check (default 2s).
+	CheckTimeout time.Duration
+}
+
+// Server serves the admin endpoints. Additional handlers (metrics, an
+// external webhook) can be mounted with Handle, also after Start.
+type Server struct {
+	addr    string
+	timeout time.Duration
+	mux     *http.ServeMux
+	srv     *http.Server
+
+	mu     sync.RWMutex
+	checks []Check
+	ln     net.Listener
+}
+
+// New builds a server; call Start to listen.
+func New(opts Options) *Server {
+	if opts.CheckTimeout <= 0 {
+		opts.CheckTimeout = defaultCheckTimeout
+	}
+	s := &Server{
+		addr:    opts.Addr,
+		timeout: opts.CheckTimeout,
+		mux:     http.NewServeMux(),
+	}
+	for _, c := range opts.Checks {
+		_ = s.AddCheck(c)
+	}
+	s.mux.HandleFunc("/healthz", s.healthz)
+	s.mux.HandleFunc("/readyz", s.readyz)
+	s.mux.HandleFunc("/version", s.version)
+	s.srv = &http.Server{Handler: s.mux, ReadHeaderTimeout: 10 * time.Second}
+	return s
+}
+
+// AddCheck registers a readiness check. Checks without Run or with a name
+// already in use are rejected and logged.
+func (s *Server) AddCheck(c Check) error {
+	err := s.addCheck(c)
+	if err != nil {
+		logger.Warn(context.Background(), "admin", "admin.check.reject",
+			slog.String("check", c.Name),
+			slog.String("err", err.Error()),
+		)
+	}
+	return err
+}
+
+func (s *Server) addCheck(c Check) error {
+	if c.Run == nil {
+		return fmt.Errorf("admin: check %q has no Run", c.Name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.checks {
+		if existing.Name == c.Name {
+			return fmt.Errorf("admin: duplicate check %q", c.Name)
+		}
+	}
+	s.checks = append(s.checks, c)
+	return nil
+}
+
+// Handle mounts h on the admin mux.
+func (s *Server) Handle(pattern string, h http.Handler) {
+	s.mux.Handle(pattern, h)
+}
+
+// Handler returns the admin mux, for serving it from an existing server.
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// Start binds the listen address and serves in the background.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.ln = ln
+	s.mu.Unlock()
+	logger.Info(context.Background(), "admin", "admin.listen", slog.String("addr", ln.Addr().String()))
+	go func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(context.Background(), "admin", "admin.serve.fail", slog.String("err", err.Error()))
+		}
+	}()
+	return nil
+}
+
+// Addr returns the bound address once started, or the configured one.
+func (s *Server) Addr() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ln != nil {
+		return s.ln.Addr().String()
+	}
+	return s.addr
+}
+
+// Shutdown stops the server, waiting for active requests until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
+
+func (s *Server) healthz(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+type checkResult struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+type readyReport struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks"`
+}
+
+// readyz runs every check concurrently and answers 503 if any fails; a
+// panicking check counts as failed.
+func (s *Server) readyz(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	checks := append([]Check(nil), s.checks...)
+	s.mu.RUnlock()
+
+	results := make([]checkResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c Check) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+			defer cancel()
+			start := time.Now()
+			defer func() {
+				if p := recover(); p != nil {
+					results[i] = checkResult{
+						Status:     "fail",
+						Error:      fmt.Sprintf("panic: %v", p),
+						DurationMS: time.Since(start).Milliseconds(),
+					}
+				}
+			}()
+			err := c.Run(ctx)
+			results[i] = checkResult{Status: "ok", DurationMS: time.Since(start).Milliseconds()}
+			if err != nil {
+				results[i].Status, results[i].Error = "fail", err.Error()
+			}
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := readyReport{Status: "ok", Checks: make(map[string]checkResult, len(checks))}
+	status := http.StatusOK
+	for i, c := range checks {
+		report.Checks[c.Name] = results[i]
+		if results[i].Error != "" {
+			report.Status, status = "fail", http.StatusServiceUnavailable
+		}
+	}
+	writeJSON(w, status, report)
+}
+
+func (s *Server) version(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"version": buildinfo.Version,
+		"commit":  buildinfo.Commit,
+		"date":    buildinfo.Date,
+		"go":      runtime.Version(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadyz(t *testing.T) {
+	srv := New(Options{Checks: []Check{
+		{Name: "ok", Run: func(context.Context) error { return nil }},
+		SaturationCheck("queue", func() (int, int) { return 1, 10 }, 0.9),
+	}})
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ready status %d: %s", rec.Code, rec.Body)
+	}
+
+	srv.AddCheck(Check{Name: "db", Run: func(context.Context) error { return errors.New("down") }})
+	srv.AddCheck(FreshnessCheck("updates", func() time.Time { return time.Now().Add(-time.Hour) }, time.Minute))
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("unready status %d", rec.Code)
+	}
+	var report readyReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Checks["db"].Error != "down" || report.Checks["updates"].Status != "fail" || report.Checks["ok"].Status != "ok" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+}
+
+func TestChecksRejectedAndRecovered(t *testing.T) {
+	srv := New(Options{Checks: []Check{
+		{Name: "nil"},
+		{Name: "boom", Run: func(context.Context) error { panic("bad check") }},
+	}})
+	if err := srv.AddCheck(Check{Name: "boom", Run: func(context.Context) error { return nil }}); err == nil {
+		t.Fatal("duplicate check accepted")
+	}
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status %d", rec.Code)
+	}
+	var report readyReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Checks) != 1 || report.Checks["boom"].Error != "panic: bad check" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+}
@@ -13,9 +13,16 @@ import (
 type TelegramConfig struct {
 	Token   string `yaml:"token" envconfig:"BOT_TOKEN"`
 	AdminID int64  `yaml:"admin_id" envconfig:"TELEGRAM_ADMIN_ID"`
-	RunMode string `yaml:"run_mode" envconfig:"TELEGRAM_RUN_MODE"`
+	// AdminIDs lists additional administrators besides AdminID.
+	AdminIDs []int64 `yaml:"admin_ids" envconfig:"TELEGRAM_ADMIN_IDS"`
+	RunMode  string  `yaml:"run_mode" envconfig:"TELEGRAM_RUN_MODE"`
 	// LongPollTimeoutSeconds defines long polling timeout; 0 -> default
 	LongPollTimeoutSeconds int `yaml:"longpoll_timeout_seconds" envconfig:"TELEGRAM_LONGPOLL_TIMEOUT_SECONDS"`
+	// SigningKey authenticates payloads the bot hands out: signed start links and typed callback data.
+	SigningKey string `yaml:"signing_key" envconfig:"TELEGRAM_SIGNING_KEY"`
+	// ShutdownTimeoutSeconds bounds draining handlers and queued sends on
+	// shutdown, and separately the OnStop hook; 0 -> 15.
+	ShutdownTimeoutSeconds int `yaml:"shutdown_timeout_seconds" envconfig:"TELEGRAM_SHUTDOWN_TIMEOUT_SECONDS"`
 }
 
 // WebhookConfig specifies webhook settings.
@@ -23,6 +30,25 @@ type WebhookConfig struct {
 	URL    string `yaml:"url" envconfig:"WEBHOOK_URL"`
 	Listen string `yaml:"listen" envconfig:"WEBHOOK_LISTEN"`
 	Port   int    `yaml:"port" envconfig:"WEBHOOK_PORT"`
+	// Path is the local path updates are served on; defaults to the path of URL.
+	Path string `yaml:"path" envconfig:"WEBHOOK_PATH"`
+	// SecretToken (1-256 of A-Z, a-z, 0-9, _ and -) is verified on every request.
+	SecretToken string `yaml:"secret_token" envconfig:"WEBHOOK_SECRET_TOKEN"`
+	// CertFile and KeyFile enable TLS on the listener; SelfSigned uploads CertFile to Telegram.
+	CertFile   string `yaml:"cert_file" envconfig:"WEBHOOK_CERT_FILE"`
+	KeyFile    string `yaml:"key_file" envconfig:"WEBHOOK_KEY_FILE"`
+	SelfSigned bool   `yaml:"self_signed" envconfig:"WEBHOOK_SELF_SIGNED"`
+	// AllowedUpdates limits the update types Telegram delivers; empty keeps the previous setting.
+	AllowedUpdates     []string `yaml:"allowed_updates" envconfig:"WEBHOOK_ALLOWED_UPDATES"`
+	MaxConnections     int      `yaml:"max_connections" envconfig:"WEBHOOK_MAX_CONNECTIONS"`
+	DropPendingUpdates bool     `yaml:"drop_pending_updates" envconfig:"WEBHOOK_DROP_PENDING_UPDATES"`
+	// RestrictIPs accepts requests only from AllowedNetworks, which default to Telegram's subnets.
+	RestrictIPs     bool     `yaml:"restrict_ips" envconfig:"WEBHOOK_RESTRICT_IPS"`
+	AllowedNetworks []string `yaml:"allowed_networks" envconfig:"WEBHOOK_ALLOWED_NETWORKS"`
+	// TrustProxy reads the client address from X-Forwarded-For for IP filtering.
+	TrustProxy bool `yaml:"trust_proxy" envconfig:"WEBHOOK_TRUST_PROXY"`
+	// External skips the built-in listener; the application mounts the handler itself.
+	External bool `yaml:"external" envconfig:"WEBHOOK_EXTERNAL"`
 }
 
 // LoggingConfig defines logging related configuration.
@@ -55,6 +81,13 @@ const (
 	UpdateInlineQuery = "inline_query"
 )
 
+const (
+	// TracingStdout writes finished spans to stdout as JSON lines.
+	TracingStdout = "stdout"
+	// TracingOTLP posts finished spans to an OTLP/HTTP collector.
+	TracingOTLP = "otlp"
+)
+
 // RateLimitConfig holds settings for rate limiting.
 // ExcludeUpdates accepts update types to bypass limiting:
 // - "callback": Telegram callback button presses
@@ -65,12 +98,72 @@ type RateLimitConfig struct {
 	ExcludeUpdates []string `yaml:"exclude_updates" envconfig:"RATE_LIMIT_EXCLUDE_UPDATES"`
 }
 
+// I18nConfig configures message catalogs for user-facing strings.
+type I18nConfig struct {
+	// DefaultLocale is used when neither user preference nor language_code match a catalog.
+	DefaultLocale string `yaml:"default_locale" envconfig:"I18N_DEFAULT_LOCALE"`
+	// Dir points to a directory with <locale>.yaml|.yml|.json catalogs; empty disables loading.
+	Dir string `yaml:"dir" envconfig:"I18N_DIR"`
+}
+
+// RolesConfig assigns custom roles to users and permissions to roles.
+type RolesConfig struct {
+	// Users maps a role name to the Telegram user IDs holding it.
+	Users map[string][]int64 `yaml:"users" ignored:"true"`
+	// Permissions maps a role name to the permissions it grants.
+	Permissions map[string][]string `yaml:"permissions" ignored:"true"`
+}
+
+// AccessConfig restricts who may talk to the bot. Deny lists always apply;
+// allow lists and invite codes are only consulted in private mode.
+type AccessConfig struct {
+	// Private rejects every user that is not an admin, allowlisted or invited.
+	Private        bool     `yaml:"private" envconfig:"ACCESS_PRIVATE"`
+	AllowUsers     []int64  `yaml:"allow_users" envconfig:"ACCESS_ALLOW_USERS"`
+	AllowChats     []int64  `yaml:"allow_chats" envconfig:"ACCESS_ALLOW_CHATS"`
+	AllowUsernames []string `yaml:"allow_usernames" envconfig:"ACCESS_ALLOW_USERNAMES"`
+	DenyUsers      []int64  `yaml:"deny_users" envconfig:"ACCESS_DENY_USERS"`
+	DenyChats      []int64  `yaml:"deny_chats" envconfig:"ACCESS_DENY_CHATS"`
+	DenyUsernames  []string `yaml:"deny_usernames" envconfig:"ACCESS_DENY_USERNAMES"`
+	// InviteCodes are reusable codes accepted via /start <code>.
+	InviteCodes []string `yaml:"invite_codes" envconfig:"ACCESS_INVITE_CODES"`
+}
+
+// AdminServerConfig enables the operational HTTP server (/healthz, /readyz, /version).
+type AdminServerConfig struct {
+	// Listen is the address of the server, for example ":9090"; empty disables it.
+	Listen string `yaml:"listen" envconfig:"ADMIN_LISTEN"`
+	// MaxUpdateAgeSeconds fails /readyz when Telegram has been silent for longer;
+	// 0 picks a default in polling mode and disables the check for webhooks.
+	MaxUpdateAgeSeconds int `yaml:"max_update_age_seconds" envconfig:"ADMIN_MAX_UPDATE_AGE_SECONDS"`
+	// QueueSaturation fails /readyz when the send queue is fuller than this fraction; 0 -> 0.9.
+	QueueSaturation float64 `yaml:"queue_saturation" envconfig:"ADMIN_QUEUE_SATURATION"`
+}
+
+// TracingConfig enables span export for updates, handlers and Telegram calls.
+type TracingConfig struct {
+	// Exporter is "stdout", "otlp" or empty to disable tracing.
+	Exporter string `yaml:"exporter" envconfig:"TRACING_EXPORTER"`
+	// Endpoint is the OTLP/HTTP collector base URL; empty -> http://localhost:4318.
+	Endpoint string `yaml:"endpoint" envconfig:"TRACING_ENDPOINT"`
+	// Headers are sent with every OTLP request, for example an API key.
+	Headers     map[string]string `yaml:"headers" envconfig:"TRACING_HEADERS"`
+	ServiceName string            `yaml:"service_name" envconfig:"TRACING_SERVICE_NAME"`
+	// SampleRatio is the fraction of updates traced, in [0, 1]; 0 -> 1.
+	SampleRatio float64 `yaml:"sample_ratio" envconfig:"TRACING_SAMPLE_RATIO"`
+}
+
 // Config aggregates the configuration that belongs to the reusable core.
 type Config struct {
-	Telegram  TelegramConfig  `yaml:"telegram"`
-	Webhook   WebhookConfig   `yaml:"webhook"`
-	Logging   LoggingConfig   `yaml:"logging"`
-	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Telegram  TelegramConfig    `yaml:"telegram"`
+	Webhook   WebhookConfig     `yaml:"webhook"`
+	Logging   LoggingConfig     `yaml:"logging"`
+	RateLimit RateLimitConfig   `yaml:"rate_limit"`
+	I18n      I18nConfig        `yaml:"i18n"`
+	Roles     RolesConfig       `yaml:"roles"`
+	Access    AccessConfig      `yaml:"access"`
+	Admin     AdminServerConfig `yaml:"admin"`
+	Tracing   TracingConfig     `yaml:"tracing"`
 }
 
 // Load reads configuration from a YAML file and environment variables.
@@ -116,11 +209,19 @@ func Normalize(cfg *Config) error {
 		if strings.TrimSpace(cfg.Webhook.URL) == "" {
 			return fmt.Errorf("webhook.url is required when telegram.run_mode is 'webhook'")
 		}
-		if strings.TrimSpace(cfg.Webhook.Listen) == "" {
-			return fmt.Errorf("webhook.listen is required when telegram.run_mode is 'webhook'")
+		if !cfg.Webhook.External {
+			if strings.TrimSpace(cfg.Webhook.Listen) == "" {
+				return fmt.Errorf("webhook.listen is required when telegram.run_mode is 'webhook'")
+			}
+			if cfg.Webhook.Port <= 0 {
+				return fmt.Errorf("webhook.port must be > 0 when telegram.run_mode is 'webhook'")
+			}
+		}
+		if !validSecretToken(cfg.Webhook.SecretToken) {
+			return fmt.Errorf("webhook.secret_token must be 1-256 characters of A-Z, a-z, 0-9, _ and -")
 		}
-		if cfg.Webhook.Port <= 0 {
-			return fmt.Errorf("webhook.port must be > 0 when telegram.run_mode is 'webhook'")
+		if cfg.Webhook.MaxConnections < 0 || cfg.Webhook.MaxConnections > 100 {
+			return fmt.Errorf("webhook.max_connections must be between 0 and 100")
 		}
 	case RunModeLongpoll:
 		if cfg.Telegram.LongPollTimeoutSeconds < 0 {
@@ -130,6 +231,30 @@ func Normalize(cfg *Config) error {
 		return fmt.Errorf("invalid telegram.run_mode %q; allowed: webhook, longpoll", cfg.Telegram.RunMode)
 	}
 	cfg.Telegram.RunMode = rm
+	if cfg.Telegram.ShutdownTimeoutSeconds < 0 {
+		return fmt.Errorf("telegram.shutdown_timeout_seconds must be >= 0")
+	}
+
+	if cfg.Admin.MaxUpdateAgeSeconds < 0 {
+		return fmt.Errorf("admin.max_update_age_seconds must be >= 0")
+	}
+	if cfg.Admin.QueueSaturation < 0 || cfg.Admin.QueueSaturation > 1 {
+		return fmt.Errorf("admin.queue_saturation must be between 0 and 1")
+	}
+	if cfg.Admin.QueueSaturation == 0 {
+		cfg.Admin.QueueSaturation = 0.9
+	}
+
+	exp := strings.ToLower(strings.TrimSpace(cfg.Tracing.Exporter))
+	switch exp {
+	case "", TracingStdout, TracingOTLP:
+	default:
+		return fmt.Errorf("invalid tracing.exporter %q; allowed: stdout, otlp", cfg.Tracing.Exporter)
+	}
+	cfg.Tracing.Exporter = exp
+	if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("tracing.sample_ratio must be between 0 and 1")
+	}
 
 	allowed := map[string]struct{}{
 		UpdateCallback:    {},
@@ -148,3 +273,16 @@ func Normalize(cfg *Config) error {
 	}
 	return nil
 }
+
+// validSecretToken reports whether token is empty or acceptable to setWebhook.
+func validSecretToken(token string) bool {
+	if len(token) > 256 {
+		return false
+	}
+	for _, r := range token {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -31,6 +31,7 @@ var allowedStatus = map[string]string{
 	"retry":        "retry",
 	"rate_limited": "rate_limited",
 	"cancelled":    "cancelled",
+	"denied":       "denied",
 }
 
 var allowedCache = map[string]string{
@@ -44,6 +45,7 @@ var allowedOutcome = map[string]string{
 	"fail":         "fail",
 	"cancelled":    "cancelled",
 	"rate_limited": "rate_limited",
+	"denied":       "denied",
 }
 
 func normalizeLevel(level string) string {
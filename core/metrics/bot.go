@@ -0,0 +1,69 @@
+package metrics
+
+import tele "gopkg.in/telebot.v4"
+
+// Standard bot metrics, recorded by the Telegram runtime in Default.
+var (
+	// Updates counts received updates by kind (see UpdateKind).
+	Updates = Default.NewCounterVec("gobot_updates_total",
+		"Telegram updates received, by kind.", "kind")
+	// HandlerDuration observes handler latency by handler name.
+	HandlerDuration = Default.NewHistogramVec("gobot_handler_duration_seconds",
+		"Handler execution time in seconds, by handler.", nil, "handler")
+	// HandlerOutcomes counts handler results by handler name and outcome.
+	HandlerOutcomes = Default.NewCounterVec("gobot_handler_outcomes_total",
+		"Handler results, by handler and outcome.", "handler", "outcome")
+	// SendAttempts counts outbound calls made by the dispatcher, retries included.
+	SendAttempts = Default.NewCounterVec("gobot_send_attempts_total",
+		"Outbound Telegram calls attempted by the dispatcher, by action.", "action")
+	// SendFailures counts dispatcher jobs that failed after all retries.
+	SendFailures = Default.NewCounterVec("gobot_send_failures_total",
+		"Outbound Telegram jobs that failed, by action and error kind.", "action", "error_kind")
+	// QueueDepth reports the number of jobs waiting in the dispatcher queue.
+	QueueDepth = Default.NewGaugeFunc("gobot_send_queue_depth",
+		"Jobs waiting in the outbound dispatcher queue.", nil)
+	// QueueCapacity reports the dispatcher queue size.
+	QueueCapacity = Default.NewGaugeFunc("gobot_send_queue_capacity",
+		"Capacity of the outbound dispatcher queue.", nil)
+	// RateLimited counts updates dropped by the rate limiter, by update kind.
+	RateLimited = Default.NewCounterVec("gobot_rate_limited_total",
+		"Updates rejected by the rate limiter, by kind.", "kind")
+	// FSMTransitions counts conversation state changes.
+	FSMTransitions = Default.NewCounterVec("gobot_fsm_transitions_total",
+		"FSM state transitions, by source and target state.", "from", "to")
+)
+
+// UpdateKind names the payload of upd, for example "message" or "callback".
+func UpdateKind(upd tele.Update) string {
+	switch {
+	case upd.Message != nil:
+		return "message"
+	case upd.EditedMessage != nil:
+		return "edited_message"
+	case upd.Callback != nil:
+		return "callback"
+	case upd.Query != nil:
+		return "inline_query"
+	case upd.InlineResult != nil:
+		return "chosen_inline_result"
+	case upd.ChannelPost != nil:
+		return "channel_post"
+	case upd.EditedChannelPost != nil:
+		return "edited_channel_post"
+	case upd.ShippingQuery != nil:
+		return "shipping_query"
+	case upd.PreCheckoutQuery != nil:
+		return "pre_checkout_query"
+	case upd.Poll != nil:
+		return "poll"
+	case upd.PollAnswer != nil:
+		return "poll_answer"
+	case upd.MyChatMember != nil:
+		return "my_chat_member"
+	case upd.ChatMember != nil:
+		return "chat_member"
+	case upd.ChatJoinRequest != nil:
+		return "chat_join_request"
+	}
+	return "other"
+}
@@ -0,0 +1,4 @@
+// Package metrics provides counters, gauges and histograms rendered in the
+// Prometheus text exposition format, plus the standard bot metrics recorded
+// by the Telegram runtime.
+package metrics
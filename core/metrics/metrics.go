@@ -0,0 +1,355 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefBuckets are histogram buckets in seconds suited to handler latencies.
+var DefBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type collector interface {
+	write(buf *bytes.Buffer)
+}
+
+// Registry holds metrics and renders them. Metric names must be unique.
+type Registry struct {
+	mu      sync.RWMutex
+	metrics map[string]collector
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]collector)}
+}
+
+// Default is the registry the standard bot metrics live in.
+var Default = NewRegistry()
+
+func (r *Registry) register(name string, c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, dup := r.metrics[name]; dup {
+		panic("metrics: duplicate metric " + name)
+	}
+	r.metrics[name] = c
+}
+
+// WriteText renders every metric in the Prometheus text format, sorted by name.
+func (r *Registry) WriteText(buf *bytes.Buffer) {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	collectors := make([]collector, len(names))
+	for i, name := range names {
+		collectors[i] = r.metrics[name]
+	}
+	r.mu.RUnlock()
+	for _, c := range collectors {
+		c.write(buf)
+	}
+}
+
+// Handler serves the registry in the Prometheus text format.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		var buf bytes.Buffer
+		r.WriteText(&buf)
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
+// family holds the children of a labelled metric keyed by their label values.
+type family[T any] struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+	newFn  func() *T
+
+	mu       sync.RWMutex
+	children map[string]*T
+	values   map[string][]string
+}
+
+func newFamily[T any](name, help, kind string, labels []string, newFn func() *T) *family[T] {
+	return &family[T]{
+		name: name, help: help, kind: kind, labels: labels, newFn: newFn,
+		children: make(map[string]*T), values: make(map[string][]string),
+	}
+}
+
+func (f *family[T]) with(values ...string) *T {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	f.mu.RLock()
+	child, ok := f.children[key]
+	f.mu.RUnlock()
+	if ok {
+		return child
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if child, ok = f.children[key]; !ok {
+		child = f.newFn()
+		f.children[key] = child
+		f.values[key] = append([]string(nil), values...)
+	}
+	return child
+}
+
+// each visits children sorted by label values.
+func (f *family[T]) each(fn func(labels string, child *T)) {
+	f.mu.RLock()
+	keys := make([]string, 0, len(f.children))
+	for key := range f.children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	type entry struct {
+		labels string
+		child  *T
+	}
+	entries := make([]entry, len(keys))
+	for i, key := range keys {
+		entries[i] = entry{formatLabels(f.labels, f.values[key]), f.children[key]}
+	}
+	f.mu.RUnlock()
+	for _, e := range entries {
+		fn(e.labels, e.child)
+	}
+}
+
+func (f *family[T]) header(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", f.name, escapeHelp(f.help), f.name, f.kind)
+}
+
+// Counter is a monotonically increasing value.
+type Counter struct {
+	bits atomic.Uint64
+}
+
+// Inc adds one.
+func (c *Counter) Inc() { c.Add(1) }
+
+// Add adds v, which must not be negative.
+func (c *Counter) Add(v float64) {
+	if v < 0 {
+		return
+	}
+	addFloat(&c.bits, v)
+}
+
+// Value returns the current count.
+func (c *Counter) Value() float64 { return math.Float64frombits(c.bits.Load()) }
+
+// CounterVec is a counter partitioned by labels.
+type CounterVec struct {
+	f *family[Counter]
+}
+
+// NewCounterVec registers a labelled counter.
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	v := &CounterVec{newFamily(name, help, "counter", labels, func() *Counter { return &Counter{} })}
+	r.register(name, v)
+	return v
+}
+
+// With returns the counter for the given label values.
+func (v *CounterVec) With(values ...string) *Counter { return v.f.with(values...) }
+
+func (v *CounterVec) write(buf *bytes.Buffer) {
+	v.f.header(buf)
+	v.f.each(func(labels string, c *Counter) {
+		fmt.Fprintf(buf, "%s%s %s\n", v.f.name, labels, formatFloat(c.Value()))
+	})
+}
+
+// Gauge is a value that can go up and down.
+type Gauge struct {
+	bits atomic.Uint64
+}
+
+// Set replaces the value.
+func (g *Gauge) Set(v float64) { g.bits.Store(math.Float64bits(v)) }
+
+// Add adds v (possibly negative).
+func (g *Gauge) Add(v float64) { addFloat(&g.bits, v) }
+
+// Value returns the current value.
+func (g *Gauge) Value() float64 { return math.Float64frombits(g.bits.Load()) }
+
+// GaugeVec is a gauge partitioned by labels.
+type GaugeVec struct {
+	f *family[Gauge]
+}
+
+// NewGaugeVec registers a labelled gauge.
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	v := &GaugeVec{newFamily(name, help, "gauge", labels, func() *Gauge { return &Gauge{} })}
+	r.register(name, v)
+	return v
+}
+
+// With returns the gauge for the given label values.
+func (v *GaugeVec) With(values ...string) *Gauge { return v.f.with(values...) }
+
+func (v *GaugeVec) write(buf *bytes.Buffer) {
+	v.f.header(buf)
+	v.f.each(func(labels string, g *Gauge) {
+		fmt.Fprintf(buf, "%s%s %s\n", v.f.name, labels, formatFloat(g.Value()))
+	})
+}
+
+// GaugeFunc is a gauge whose value is read at scrape time.
+type GaugeFunc struct {
+	name string
+	help string
+	fn   atomic.Pointer[func() float64]
+}
+
+// NewGaugeFunc registers a gauge read from fn; fn may be replaced with Set.
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{name: name, help: help}
+	g.Set(fn)
+	r.register(name, g)
+	return g
+}
+
+// Set replaces the value source; nil reports zero.
+func (g *GaugeFunc) Set(fn func() float64) { g.fn.Store(&fn) }
+
+func (g *GaugeFunc) write(buf *bytes.Buffer) {
+	value := 0.0
+	if fn := g.fn.Load(); fn != nil && *fn != nil {
+		value = (*fn)()
+	}
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n", g.name, escapeHelp(g.help), g.name, g.name, formatFloat(value))
+}
+
+// Histogram counts observations into cumulative buckets.
+type Histogram struct {
+	upper  []float64
+	counts []atomic.Uint64
+	count  atomic.Uint64
+	sum    atomic.Uint64
+}
+
+// Observe records v.
+func (h *Histogram) Observe(v float64) {
+	i := sort.SearchFloat64s(h.upper, v)
+	if i < len(h.counts) {
+		h.counts[i].Add(1)
+	}
+	h.count.Add(1)
+	addFloat(&h.sum, v)
+}
+
+// HistogramVec is a histogram partitioned by labels.
+type HistogramVec struct {
+	f *family[Histogram]
+}
+
+// NewHistogramVec registers a labelled histogram; nil buckets means DefBuckets.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	v := &HistogramVec{}
+	v.f = newFamily(name, help, "histogram", labels, func() *Histogram {
+		return &Histogram{upper: buckets, counts: make([]atomic.Uint64, len(buckets))}
+	})
+	r.register(name, v)
+	return v
+}
+
+// With returns the histogram for the given label values.
+func (v *HistogramVec) With(values ...string) *Histogram { return v.f.with(values...) }
+
+func (v *HistogramVec) write(buf *bytes.Buffer) {
+	v.f.header(buf)
+	v.f.each(func(labels string, h *Histogram) {
+		cumulative := uint64(0)
+		for i, upper := range h.upper {
+			cumulative += h.counts[i].Load()
+			fmt.Fprintf(buf, "%s_bucket%s %d\n", v.f.name, withLabel(labels, "le", formatFloat(upper)), cumulative)
+		}
+		count := h.count.Load()
+		fmt.Fprintf(buf, "%s_bucket%s %d\n", v.f.name, withLabel(labels, "le", "+Inf"), count)
+		fmt.Fprintf(buf, "%s_sum%s %s\n", v.f.name, labels, formatFloat(math.Float64frombits(h.sum.Load())))
+		fmt.Fprintf(buf, "%s_count%s %d\n", v.f.name, labels, count)
+	})
+}
+
+func addFloat(bits *atomic.Uint64, v float64) {
+	for {
+		old := bits.Load()
+		if bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+v)) {
+			return
+		}
+	}
+}
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(name)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabel(values[i]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// withLabel appends name="value" to an already formatted label set.
+func withLabel(labels, name, value string) string {
+	pair := name + `="` + value + `"`
+	if labels == "" {
+		return "{" + pair + "}"
+	}
+	return labels[:len(labels)-1] + "," + pair + "}"
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
+
+func escapeHelp(s string) string { return helpEscaper.Replace(s) }
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	r := NewRegistry()
+	sends := r.NewCounterVec("sends_total", "Sends.", "action", "error_kind")
+	sends.With("send", `bad"kind`).Add(2)
+	latency := r.NewHistogramVec("latency_seconds", "Latency.", []float64{0.1, 1}, "handler")
+	latency.With("start").Observe(0.05)
+	latency.With("start").Observe(0.5)
+	latency.With("start").Observe(3)
+	r.NewGaugeFunc("queue_depth", "Queue.", func() float64 { return 4 })
+
+	var buf bytes.Buffer
+	r.WriteText(&buf)
+	want := `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{handler="start",le="0.1"} 1
+latency_seconds_bucket{handler="start",le="1"} 2
+latency_seconds_bucket{handler="start",le="+Inf"} 3
+latency_seconds_sum{handler="start"} 3.55
+latency_seconds_count{handler="start"} 3
+# HELP queue_depth Queue.
+# TYPE queue_depth gauge
+queue_depth 4
+# HELP sends_total Sends.
+# TYPE sends_total counter
+sends_total{action="send",error_kind="bad\"kind"} 2
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package access
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/telegram/commands"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// BanCommand returns an admin-only command: /ban <user_id> [reason], or a
+// reply to the user's message with /ban [reason].
+func (g *Guard) BanCommand() commands.Command {
+	return commands.Command{
+		Description:    "Ban a user",
+		DescriptionKey: "commands.ban",
+		AdminOnly:      true,
+		Handler: func(c tele.Context) error {
+			userID, reason, ok := targetFromMessage(c)
+			if !ok {
+				return tghelpers.SendText(c, i18n.T(c, i18n.KeyBanUsage))
+			}
+			if g.IsAdmin(userID) {
+				return tghelpers.SendText(c, i18n.T(c, i18n.KeyBanAdmin))
+			}
+			ctx := tghelpers.BuildContext(c)
+			if err := g.store.Ban(ctx, userID, c.Sender().ID, reason); err != nil {
+				return err
+			}
+			logger.Info(ctx, "tg", "access.ban",
+				slog.String("status", "ok"),
+				slog.Int64("target_id", userID),
+				slog.String("reason", logger.SanitizeLimit(reason, 128)),
+			)
+			return tghelpers.SendText(c, i18n.T(c, i18n.KeyUserBanned, i18n.Args{"id": userID}))
+		},
+	}
+}
+
+// UnbanCommand returns an admin-only command: /unban <user_id>.
+func (g *Guard) UnbanCommand() commands.Command {
+	return commands.Command{
+		Description:    "Unban a user",
+		DescriptionKey: "commands.unban",
+		AdminOnly:      true,
+		Handler: func(c tele.Context) error {
+			userID, _, ok := targetFromMessage(c)
+			if !ok {
+				return tghelpers.SendText(c, i18n.T(c, i18n.KeyUnbanUsage))
+			}
+			ctx := tghelpers.BuildContext(c)
+			if err := g.store.Unban(ctx, userID); err != nil {
+				return err
+			}
+			logger.Info(ctx, "tg", "access.unban",
+				slog.String("status", "ok"),
+				slog.Int64("target_id", userID),
+			)
+			return tghelpers.SendText(c, i18n.T(c, i18n.KeyUserUnbanned, i18n.Args{"id": userID}))
+		},
+	}
+}
+
+// InviteCommand returns an admin-only command issuing a one-time invite
+// link valid for ttl (no expiry when ttl <= 0).
+func (g *Guard) InviteCommand(ttl time.Duration) commands.Command {
+	return commands.Command{
+		Description:    "Create an invite link",
+		DescriptionKey: "commands.invite",
+		AdminOnly:      true,
+		Handler: func(c tele.Context) error {
+			code, err := newInviteCode()
+			if err != nil {
+				return err
+			}
+			inv := Invite{Code: code, CreatedBy: c.Sender().ID, MaxUses: 1}
+			if ttl > 0 {
+				inv.ExpiresAt = time.Now().Add(ttl)
+			}
+			if err := g.store.CreateInvite(tghelpers.BuildContext(c), inv); err != nil {
+				return err
+			}
+			link := code
+			if bot := commands.BotUsername(c); bot != "" {
+				if l, err := commands.StartLink(bot, code); err == nil {
+					link = l
+				}
+			}
+			return tghelpers.SendText(c, i18n.T(c, i18n.KeyInviteCreated, i18n.Args{"link": link}))
+		},
+	}
+}
+
+// targetFromMessage extracts the target user from a reply or the first argument.
+func targetFromMessage(c tele.Context) (int64, string, bool) {
+	msg := c.Message()
+	if msg == nil {
+		return 0, "", false
+	}
+	payload := strings.TrimSpace(msg.Payload)
+	if msg.ReplyTo != nil && msg.ReplyTo.Sender != nil {
+		return msg.ReplyTo.Sender.ID, payload, true
+	}
+	idStr, reason, _ := strings.Cut(payload, " ")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id == 0 {
+		return 0, "", false
+	}
+	return id, strings.TrimSpace(reason), true
+}
+
+func newInviteCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("access: generate invite: %w", err)
+	}
+	return "inv_" + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,3 @@
+// Package access restricts which users and chats may interact with the bot:
+// allow/deny lists, private mode with invite codes, and persistent bans.
+package access
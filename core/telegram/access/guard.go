@@ -0,0 +1,271 @@
+package access
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+
+	coreconfig "github.com/m3rciful/gobot/core/config"
+	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/telegram/commands"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Rejection reasons reported in access.rejected log events. ReasonStoreError
+// only occurs in private mode; public bots let updates through when the store fails.
+const (
+	ReasonDenylist   = "denylist"
+	ReasonBanned     = "banned"
+	ReasonNotAllowed = "not_allowed"
+	ReasonStoreError = "store_error"
+)
+
+// Options configures a Guard.
+type Options struct {
+	Config coreconfig.AccessConfig
+	// Admins always pass the guard and may use the admin commands.
+	Admins []int64
+	// Store persists bans, members and invites; defaults to NewMemoryStore.
+	Store Store
+	// OnReject runs for rejected updates; by default they are dropped silently.
+	OnReject func(c tele.Context, reason string) error
+	// OnInvited runs after a user joined with an invite code, before the
+	// update continues to its handler (usually /start).
+	OnInvited tele.HandlerFunc
+}
+
+// Guard enforces allow/deny lists, bans and private mode for incoming updates.
+type Guard struct {
+	lists     atomic.Pointer[lists]
+	store     Store
+	admins    []int64
+	onReject  func(c tele.Context, reason string) error
+	onInvited tele.HandlerFunc
+}
+
+// NewGuard creates a Guard from opts.
+func NewGuard(opts Options) *Guard {
+	g := &Guard{
+		store:     opts.Store,
+		admins:    append([]int64(nil), opts.Admins...),
+		onReject:  opts.OnReject,
+		onInvited: opts.OnInvited,
+	}
+	if g.store == nil {
+		g.store = NewMemoryStore()
+	}
+	g.lists.Store(newLists(opts.Config, g.admins))
+	return g
+}
+
+// NewFromConfig creates a Guard using the access section and admins of cfg.
+func NewFromConfig(cfg *coreconfig.Config, store Store) *Guard {
+	if cfg == nil {
+		return NewGuard(Options{Store: store})
+	}
+	admins := append([]int64{cfg.Telegram.AdminID}, cfg.Telegram.AdminIDs...)
+	return NewGuard(Options{Config: cfg.Access, Admins: admins, Store: store})
+}
+
+// Store returns the backing store.
+func (g *Guard) Store() Store {
+	return g.store
+}
+
+// Reload atomically replaces the allow/deny lists.
+func (g *Guard) Reload(cfg coreconfig.AccessConfig) {
+	g.lists.Store(newLists(cfg, g.admins))
+	logger.TWire.Info("tg.wire",
+		slog.String("event", "access.reload"),
+		slog.Bool("private", cfg.Private),
+		slog.Int("allow_users", len(cfg.AllowUsers)),
+		slog.Int("deny_users", len(cfg.DenyUsers)),
+	)
+}
+
+// WatchFile polls path every interval and reloads the access section when
+// the file changes. load defaults to coreconfig.Load. It blocks until ctx is done.
+func (g *Guard) WatchFile(ctx context.Context, path string, interval time.Duration, load func(path string) (coreconfig.AccessConfig, error)) {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	if load == nil {
+		load = func(p string) (coreconfig.AccessConfig, error) {
+			cfg, err := coreconfig.Load(p)
+			if err != nil {
+				return coreconfig.AccessConfig{}, err
+			}
+			return cfg.Access, nil
+		}
+	}
+	var lastMod time.Time
+	if st, err := os.Stat(path); err == nil {
+		lastMod = st.ModTime()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		st, err := os.Stat(path)
+		if err != nil || !st.ModTime().After(lastMod) {
+			continue
+		}
+		lastMod = st.ModTime()
+		cfg, err := load(path)
+		if err != nil {
+			logger.TWire.Warn("tg.wire",
+				slog.String("event", "access.reload"),
+				slog.String("status", "fail"),
+				slog.String("err", err.Error()),
+			)
+			continue
+		}
+		g.Reload(cfg)
+	}
+}
+
+// IsAdmin reports whether userID is one of the configured admins.
+func (g *Guard) IsAdmin(userID int64) bool {
+	return has(g.lists.Load().admins, userID)
+}
+
+// Middleware returns the access-enforcing middleware. It should run after
+// LoggerMiddleware so rejections carry the update metadata.
+func (g *Guard) Middleware() tele.MiddlewareFunc {
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			reason, invited := g.check(c)
+			if reason != "" {
+				return g.reject(c, reason)
+			}
+			if invited && g.onInvited != nil {
+				if err := g.onInvited(c); err != nil {
+					return err
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// check returns a rejection reason (empty when allowed) and whether the user
+// was onboarded by this update.
+func (g *Guard) check(c tele.Context) (string, bool) {
+	l := g.lists.Load()
+	user, chat := c.Sender(), c.Chat()
+	ctx := tghelpers.BuildContext(c)
+
+	if user != nil && has(l.admins, user.ID) {
+		return "", false
+	}
+	if chat != nil && has(l.denyChats, chat.ID) {
+		return ReasonDenylist, false
+	}
+	if user == nil {
+		if !l.private || (chat != nil && has(l.allowChats, chat.ID)) {
+			return "", false
+		}
+		return ReasonNotAllowed, false
+	}
+	if has(l.denyUsers, user.ID) || has(l.denyUsernames, normalizeUsername(user.Username)) {
+		return ReasonDenylist, false
+	}
+	banned, err := g.store.IsBanned(ctx, user.ID)
+	if err != nil {
+		logger.Warn(ctx, "tg", "access.store.fail", slog.String("err", err.Error()))
+		// A public bot stays usable while bans cannot be checked; a private
+		// one must not let unknown users in.
+		if !l.private {
+			return "", false
+		}
+		return ReasonStoreError, false
+	}
+	if banned {
+		return ReasonBanned, false
+	}
+	if !l.private {
+		return "", false
+	}
+	if has(l.allowUsers, user.ID) || (chat != nil && has(l.allowChats, chat.ID)) {
+		return "", false
+	}
+	if user.Username != "" && has(l.allowUsernames, normalizeUsername(user.Username)) {
+		return "", false
+	}
+	member, err := g.store.IsMember(ctx, user.ID)
+	if err != nil {
+		logger.Warn(ctx, "tg", "access.store.fail", slog.String("err", err.Error()))
+		return ReasonStoreError, false
+	}
+	if member {
+		return "", false
+	}
+	if g.redeem(c, l) {
+		return "", true
+	}
+	return ReasonNotAllowed, false
+}
+
+// redeem accepts "/start <code>" with a configured or issued invite code.
+func (g *Guard) redeem(c tele.Context, l *lists) bool {
+	if c.Callback() != nil {
+		return false
+	}
+	start, ok := commands.StartPayloadFrom(c, nil)
+	if !ok {
+		return false
+	}
+	code := start.Raw
+	ctx := tghelpers.BuildContext(c)
+	ok = has(l.inviteCodes, code)
+	if !ok {
+		var err error
+		ok, err = g.store.RedeemInvite(ctx, code)
+		if err != nil {
+			logger.Warn(ctx, "tg", "access.store.fail", slog.String("err", err.Error()))
+			return false
+		}
+	}
+	if !ok {
+		return false
+	}
+	if err := g.store.AddMember(ctx, c.Sender().ID); err != nil {
+		logger.Warn(ctx, "tg", "access.store.fail", slog.String("err", err.Error()))
+		return false
+	}
+	logger.Info(ctx, "tg", "access.invited",
+		slog.String("status", "ok"),
+		slog.Int64("user_id", c.Sender().ID),
+	)
+	return true
+}
+
+func (g *Guard) reject(c tele.Context, reason string) error {
+	attrs := []slog.Attr{
+		slog.String("status", "denied"),
+		slog.String("outcome", "denied"),
+		slog.String("reason", reason),
+	}
+	if user := c.Sender(); user != nil {
+		attrs = append(attrs, slog.Int64("user_id", user.ID))
+		if user.Username != "" {
+			attrs = append(attrs, slog.String("username", logger.SanitizeLimit(user.Username, 64)))
+		}
+	}
+	if chat := c.Chat(); chat != nil {
+		attrs = append(attrs, slog.Int64("chat_id", chat.ID))
+	}
+	logger.Info(tghelpers.BuildContext(c), "tg", "access.rejected", attrs...)
+	if g.onReject != nil {
+		return g.onReject(c, reason)
+	}
+	return nil
+}
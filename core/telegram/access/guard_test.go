@@ -0,0 +1,111 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	coreconfig "github.com/m3rciful/gobot/core/config"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+type failingStore struct {
+	*MemoryStore
+}
+
+func (failingStore) IsBanned(context.Context, int64) (bool, error) {
+	return false, errors.New("db down")
+}
+
+func newContext(t *testing.T, userID int64, text string) tele.Context {
+	t.Helper()
+	bot, err := tele.NewBot(tele.Settings{Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bot.NewContext(tele.Update{Message: &tele.Message{
+		Sender: &tele.User{ID: userID, Username: "user"},
+		Chat:   &tele.Chat{ID: userID, Type: tele.ChatPrivate},
+		Text:   text,
+	}})
+}
+
+// run passes one update through the guard and returns the rejection reason.
+func run(t *testing.T, g *Guard, userID int64, text string) string {
+	t.Helper()
+	var reason string
+	g.onReject = func(_ tele.Context, r string) error {
+		reason = r
+		return nil
+	}
+	passed := false
+	if err := g.Middleware()(func(tele.Context) error {
+		passed = true
+		return nil
+	})(newContext(t, userID, text)); err != nil {
+		t.Fatal(err)
+	}
+	if passed == (reason != "") {
+		t.Fatalf("passed = %v with reason %q", passed, reason)
+	}
+	return reason
+}
+
+func TestGuardLists(t *testing.T) {
+	store := NewMemoryStore()
+	g := NewGuard(Options{
+		Config: coreconfig.AccessConfig{DenyUsers: []int64{2}, DenyUsernames: []string{"@Spam"}},
+		Admins: []int64{1},
+		Store:  store,
+	})
+	_ = store.Ban(context.Background(), 3, 1, "")
+
+	cases := []struct {
+		user int64
+		want string
+	}{
+		{1, ""},
+		{2, ReasonDenylist},
+		{3, ReasonBanned},
+		{4, ""},
+	}
+	for _, tc := range cases {
+		if got := run(t, g, tc.user, "hi"); got != tc.want {
+			t.Errorf("user %d: reason %q, want %q", tc.user, got, tc.want)
+		}
+	}
+}
+
+func TestGuardPrivateInvite(t *testing.T) {
+	g := NewGuard(Options{
+		Config: coreconfig.AccessConfig{Private: true, AllowUsers: []int64{5}, InviteCodes: []string{"welcome"}},
+	})
+	if got := run(t, g, 5, "hi"); got != "" {
+		t.Fatalf("allowlisted user rejected: %q", got)
+	}
+	if got := run(t, g, 6, "hi"); got != ReasonNotAllowed {
+		t.Fatalf("unknown user: %q", got)
+	}
+	if got := run(t, g, 6, "/start wrong"); got != ReasonNotAllowed {
+		t.Fatalf("wrong code: %q", got)
+	}
+	if got := run(t, g, 6, "/start welcome"); got != "" {
+		t.Fatalf("invite rejected: %q", got)
+	}
+	if got := run(t, g, 6, "hi"); got != "" {
+		t.Fatalf("member rejected after invite: %q", got)
+	}
+}
+
+func TestGuardStoreFailure(t *testing.T) {
+	store := failingStore{NewMemoryStore()}
+	public := NewGuard(Options{Store: store})
+	if got := run(t, public, 7, "hi"); got != "" {
+		t.Fatalf("public bot rejected on store error: %q", got)
+	}
+	private := NewGuard(Options{Config: coreconfig.AccessConfig{Private: true}, Store: store})
+	if got := run(t, private, 7, "hi"); got != ReasonStoreError {
+		t.Fatalf("private bot on store error: %q", got)
+	}
+}
@@ -0,0 +1,69 @@
+package access
+
+import (
+	"strings"
+
+	coreconfig "github.com/m3rciful/gobot/core/config"
+)
+
+// lists is an immutable snapshot of the access configuration.
+type lists struct {
+	private        bool
+	admins         map[int64]struct{}
+	allowUsers     map[int64]struct{}
+	allowChats     map[int64]struct{}
+	allowUsernames map[string]struct{}
+	denyUsers      map[int64]struct{}
+	denyChats      map[int64]struct{}
+	denyUsernames  map[string]struct{}
+	inviteCodes    map[string]struct{}
+}
+
+func newLists(cfg coreconfig.AccessConfig, admins []int64) *lists {
+	l := &lists{
+		private:        cfg.Private,
+		admins:         idSet(admins),
+		allowUsers:     idSet(cfg.AllowUsers),
+		allowChats:     idSet(cfg.AllowChats),
+		allowUsernames: usernameSet(cfg.AllowUsernames),
+		denyUsers:      idSet(cfg.DenyUsers),
+		denyChats:      idSet(cfg.DenyChats),
+		denyUsernames:  usernameSet(cfg.DenyUsernames),
+		inviteCodes:    make(map[string]struct{}, len(cfg.InviteCodes)),
+	}
+	for _, code := range cfg.InviteCodes {
+		if code = strings.TrimSpace(code); code != "" {
+			l.inviteCodes[code] = struct{}{}
+		}
+	}
+	return l
+}
+
+func idSet(ids []int64) map[int64]struct{} {
+	set := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		if id != 0 {
+			set[id] = struct{}{}
+		}
+	}
+	return set
+}
+
+func usernameSet(names []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		if n = normalizeUsername(n); n != "" {
+			set[n] = struct{}{}
+		}
+	}
+	return set
+}
+
+func normalizeUsername(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "@"))
+}
+
+func has[K comparable](set map[K]struct{}, key K) bool {
+	_, ok := set[key]
+	return ok
+}
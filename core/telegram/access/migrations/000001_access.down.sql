@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS access_invites;
+DROP TABLE IF EXISTS access_members;
+DROP TABLE IF EXISTS access_bans;
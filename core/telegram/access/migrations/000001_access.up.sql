@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS access_bans (
+    user_id    BIGINT PRIMARY KEY,
+    banned_by  BIGINT,
+    reason     TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS access_members (
+    user_id    BIGINT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS access_invites (
+    code       TEXT PRIMARY KEY,
+    created_by BIGINT,
+    max_uses   INT,
+    uses       INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ
+);
@@ -0,0 +1,231 @@
+package access
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Invite is a one-time or limited-use invite code issued by an admin.
+type Invite struct {
+	Code      string
+	CreatedBy int64
+	MaxUses   int
+	Uses      int
+	ExpiresAt time.Time
+}
+
+// Store persists bans, onboarded members and issued invites.
+type Store interface {
+	IsBanned(ctx context.Context, userID int64) (bool, error)
+	Ban(ctx context.Context, userID, by int64, reason string) error
+	Unban(ctx context.Context, userID int64) error
+
+	IsMember(ctx context.Context, userID int64) (bool, error)
+	AddMember(ctx context.Context, userID int64) error
+
+	CreateInvite(ctx context.Context, inv Invite) error
+	// RedeemInvite consumes one use of code and reports whether it was valid.
+	RedeemInvite(ctx context.Context, code string) (bool, error)
+}
+
+// MemoryStore keeps access state in process memory; it is lost on restart.
+type MemoryStore struct {
+	mu      sync.Mutex
+	bans    map[int64]string
+	members map[int64]struct{}
+	invites map[string]*Invite
+}
+
+// NewMemoryStore creates an empty in-memory Store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		bans:    make(map[int64]string),
+		members: make(map[int64]struct{}),
+		invites: make(map[string]*Invite),
+	}
+}
+
+// IsBanned reports whether the user is banned.
+func (s *MemoryStore) IsBanned(_ context.Context, userID int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.bans[userID]
+	return ok, nil
+}
+
+// Ban records a ban for the user.
+func (s *MemoryStore) Ban(_ context.Context, userID, _ int64, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bans[userID] = reason
+	return nil
+}
+
+// Unban lifts a ban for the user.
+func (s *MemoryStore) Unban(_ context.Context, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.bans, userID)
+	return nil
+}
+
+// IsMember reports whether the user has been onboarded.
+func (s *MemoryStore) IsMember(_ context.Context, userID int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.members[userID]
+	return ok, nil
+}
+
+// AddMember marks the user as onboarded.
+func (s *MemoryStore) AddMember(_ context.Context, userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.members[userID] = struct{}{}
+	return nil
+}
+
+// CreateInvite stores a new invite code.
+func (s *MemoryStore) CreateInvite(_ context.Context, inv Invite) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.invites[inv.Code]; exists {
+		return fmt.Errorf("access: invite %q already exists", inv.Code)
+	}
+	s.invites[inv.Code] = &inv
+	return nil
+}
+
+// RedeemInvite consumes one use of the invite if it is still valid.
+func (s *MemoryStore) RedeemInvite(_ context.Context, code string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inv, ok := s.invites[code]
+	if !ok {
+		return false, nil
+	}
+	if !inv.ExpiresAt.IsZero() && time.Now().After(inv.ExpiresAt) {
+		delete(s.invites, code)
+		return false, nil
+	}
+	inv.Uses++
+	if inv.MaxUses > 0 && inv.Uses >= inv.MaxUses {
+		delete(s.invites, code)
+	}
+	return true, nil
+}
+
+// Migrations holds the golang-migrate files creating the SQLStore tables,
+// for bots that copy them into their own migrations directory.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// SQLStore persists access state in PostgreSQL. Create its tables with
+// EnsureSchema or by applying Migrations.
+type SQLStore struct {
+	DB *sqlx.DB
+}
+
+// EnsureSchema creates the access tables when they do not exist yet.
+func (s SQLStore) EnsureSchema(ctx context.Context) error {
+	schema, err := Migrations.ReadFile("migrations/000001_access.up.sql")
+	if err != nil {
+		return fmt.Errorf("access: read schema: %w", err)
+	}
+	if _, err := s.DB.ExecContext(ctx, string(schema)); err != nil {
+		return fmt.Errorf("access: create schema: %w", err)
+	}
+	return nil
+}
+
+// IsBanned reports whether the user is banned.
+func (s SQLStore) IsBanned(ctx context.Context, userID int64) (bool, error) {
+	return s.exists(ctx, `SELECT 1 FROM access_bans WHERE user_id = $1`, userID)
+}
+
+// Ban records a ban for the user.
+func (s SQLStore) Ban(ctx context.Context, userID, by int64, reason string) error {
+	_, err := s.DB.ExecContext(ctx,
+		`INSERT INTO access_bans (user_id, banned_by, reason) VALUES ($1, $2, $3)
+		 ON CONFLICT (user_id) DO UPDATE SET banned_by = EXCLUDED.banned_by, reason = EXCLUDED.reason`,
+		userID, by, reason)
+	if err != nil {
+		return fmt.Errorf("access: ban user: %w", err)
+	}
+	return nil
+}
+
+// Unban lifts a ban for the user.
+func (s SQLStore) Unban(ctx context.Context, userID int64) error {
+	if _, err := s.DB.ExecContext(ctx, `DELETE FROM access_bans WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("access: unban user: %w", err)
+	}
+	return nil
+}
+
+// IsMember reports whether the user has been onboarded.
+func (s SQLStore) IsMember(ctx context.Context, userID int64) (bool, error) {
+	return s.exists(ctx, `SELECT 1 FROM access_members WHERE user_id = $1`, userID)
+}
+
+// AddMember marks the user as onboarded.
+func (s SQLStore) AddMember(ctx context.Context, userID int64) error {
+	_, err := s.DB.ExecContext(ctx,
+		`INSERT INTO access_members (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING`, userID)
+	if err != nil {
+		return fmt.Errorf("access: add member: %w", err)
+	}
+	return nil
+}
+
+// CreateInvite stores a new invite code.
+func (s SQLStore) CreateInvite(ctx context.Context, inv Invite) error {
+	var expires any
+	if !inv.ExpiresAt.IsZero() {
+		expires = inv.ExpiresAt
+	}
+	_, err := s.DB.ExecContext(ctx,
+		`INSERT INTO access_invites (code, created_by, max_uses, expires_at) VALUES ($1, $2, $3, $4)`,
+		inv.Code, inv.CreatedBy, inv.MaxUses, expires)
+	if err != nil {
+		return fmt.Errorf("access: create invite: %w", err)
+	}
+	return nil
+}
+
+// RedeemInvite consumes one use of the invite if it is still valid.
+func (s SQLStore) RedeemInvite(ctx context.Context, code string) (bool, error) {
+	res, err := s.DB.ExecContext(ctx,
+		`UPDATE access_invites SET uses = uses + 1
+		 WHERE code = $1
+		   AND (expires_at IS NULL OR expires_at > now())
+		   AND (max_uses IS NULL OR max_uses <= 0 OR uses < max_uses)`, code)
+	if err != nil {
+		return false, fmt.Errorf("access: redeem invite: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("access: redeem invite: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (s SQLStore) exists(ctx context.Context, query string, args ...any) (bool, error) {
+	var one int
+	err := s.DB.GetContext(ctx, &one, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("access: query: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,45 @@
+package telegram
+
+import (
+	"time"
+
+	"github.com/m3rciful/gobot/core/admin"
+	coreconfig "github.com/m3rciful/gobot/core/config"
+	"github.com/m3rciful/gobot/core/metrics"
+)
+
+const (
+	defaultMaxUpdateAge    = 2 * time.Minute
+	defaultQueueSaturation = 0.9
+)
+
+// newAdminServer builds the admin server with the built-in readiness checks:
+// database ping, freshness of updates from Telegram and send queue saturation.
+// Metrics are served on /metrics and an external webhook is mounted at its path.
+func newAdminServer(cfg *coreconfig.Config, opts RunOptions, rt Runtime) *admin.Server {
+	srv := admin.New(admin.Options{Addr: cfg.Admin.Listen, Checks: opts.HealthChecks})
+	if opts.DB != nil {
+		srv.AddCheck(admin.PingCheck("db", opts.DB))
+	}
+
+	maxAge := time.Duration(cfg.Admin.MaxUpdateAgeSeconds) * time.Second
+	if maxAge == 0 && rt.Webhook == nil {
+		// A long poll returns at least once per timeout, even without updates.
+		maxAge = max(defaultMaxUpdateAge, 3*time.Duration(cfg.Telegram.LongPollTimeoutSeconds)*time.Second)
+	}
+	if maxAge > 0 {
+		srv.AddCheck(admin.FreshnessCheck("telegram_updates", rt.Heartbeat.Last, maxAge))
+	}
+
+	saturation := cfg.Admin.QueueSaturation
+	if saturation <= 0 {
+		saturation = defaultQueueSaturation
+	}
+	srv.AddCheck(admin.SaturationCheck("send_queue", rt.Dispatcher.QueueDepth, saturation))
+
+	srv.Handle("/metrics", metrics.Default.Handler())
+	if rt.Webhook != nil && rt.Webhook.external {
+		srv.Handle(rt.Webhook.Path(), rt.Webhook)
+	}
+	return srv
+}
@@ -0,0 +1,278 @@
+package callbacks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// MaxDataLen is the callback_data limit imposed by Telegram, in bytes.
+const MaxDataLen = 64
+
+const defaultMACSize = 6
+
+// Codec errors. ErrTampered and ErrStale implement the handler error code contract.
+var (
+	ErrMalformed = &CodecError{code: "CALLBACK_MALFORMED", msg: "malformed callback data"}
+	ErrTampered  = &CodecError{code: "CALLBACK_TAMPERED", msg: "callback signature mismatch"}
+	ErrStale     = &CodecError{code: "CALLBACK_STALE", msg: "callback data is outdated"}
+	ErrTooLong   = errors.New("encoded callback data exceeds 64 bytes")
+)
+
+// CodecError reports callback data that cannot be trusted or decoded.
+type CodecError struct {
+	code string
+	msg  string
+}
+
+func (e *CodecError) Error() string { return e.msg }
+
+// Code implements the error code contract used in handler logs.
+func (e *CodecError) Code() string { return e.code }
+
+// CodecOptions configures a Codec.
+type CodecOptions struct {
+	// Key enables HMAC-SHA256 signing (usually telegram.signing_key); empty disables it.
+	Key string
+	// MACSize is the number of signature bytes kept (default 6).
+	MACSize int
+	// MaxAge rejects data older than this; zero disables the timestamp.
+	MaxAge time.Duration
+}
+
+// Codec encodes structs into compact callback payloads: a version, an
+// optional timestamp and the exported fields as varints and length-prefixed
+// strings, followed by an optional truncated HMAC, all in unpadded base64url.
+// Supported field kinds are integers, bool, float, string, time.Time and
+// nested structs.
+type Codec struct {
+	key     []byte
+	macSize int
+	maxAge  time.Duration
+	now     func() time.Time
+}
+
+// NewCodec creates a codec.
+func NewCodec(opts CodecOptions) *Codec {
+	c := &Codec{maxAge: opts.MaxAge, now: time.Now}
+	if opts.Key != "" {
+		c.key = []byte(opts.Key)
+		c.macSize = opts.MACSize
+		if c.macSize <= 0 || c.macSize > sha256.Size {
+			c.macSize = defaultMACSize
+		}
+	}
+	return c
+}
+
+// Signed reports whether payloads carry an HMAC.
+func (c *Codec) Signed() bool {
+	return c != nil && len(c.key) > 0
+}
+
+// Encode serialises v (a struct or pointer to struct) for the callback key,
+// tagged with version. The signature covers key, version and body, so data
+// minted for one callback is rejected by another.
+func (c *Codec) Encode(key string, version uint, v any) (string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return "", fmt.Errorf("callbacks: cannot encode %T", v)
+	}
+	buf := binary.AppendUvarint(nil, uint64(version))
+	if c.maxAge > 0 {
+		buf = binary.AppendUvarint(buf, uint64(c.now().Unix()))
+	}
+	buf, err := appendStruct(buf, rv)
+	if err != nil {
+		return "", err
+	}
+	if c.Signed() {
+		buf = append(buf, c.mac(key, uint64(version), buf)...)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// Decode verifies data for the callback key and fills v (a pointer to
+// struct). It returns ErrTampered for a bad signature or another key and
+// ErrStale for another version or data older than MaxAge.
+func (c *Codec) Decode(key, data string, version uint, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("callbacks: cannot decode into %T", v)
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil {
+		return ErrMalformed
+	}
+	if c.Signed() {
+		if len(buf) < c.macSize {
+			return ErrTampered
+		}
+		body, sig := buf[:len(buf)-c.macSize], buf[len(buf)-c.macSize:]
+		got, n := binary.Uvarint(body)
+		if n <= 0 {
+			return ErrTampered
+		}
+		if !hmac.Equal(sig, c.mac(key, got, body)) {
+			return ErrTampered
+		}
+		buf = body
+	}
+	d := decoder{buf: buf}
+	if got, ok := d.uvarint(); !ok {
+		return ErrMalformed
+	} else if got != uint64(version) {
+		return ErrStale
+	}
+	if c.maxAge > 0 {
+		ts, ok := d.uvarint()
+		if !ok {
+			return ErrMalformed
+		}
+		if c.now().Sub(time.Unix(int64(ts), 0)) > c.maxAge {
+			return ErrStale
+		}
+	}
+	if !d.readStruct(rv.Elem()) || len(d.buf) != 0 {
+		return ErrMalformed
+	}
+	return nil
+}
+
+// mac signs key|version|body.
+func (c *Codec) mac(key string, version uint64, body []byte) []byte {
+	h := hmac.New(sha256.New, c.key)
+	h.Write([]byte(key))
+	h.Write([]byte{'|'})
+	h.Write(binary.AppendUvarint(nil, version))
+	h.Write([]byte{'|'})
+	h.Write(body)
+	return h.Sum(nil)[:c.macSize]
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func appendStruct(buf []byte, rv reflect.Value) ([]byte, error) {
+	t := rv.Type()
+	for i := 0; i < rv.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		f := rv.Field(i)
+		switch f.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			buf = binary.AppendVarint(buf, f.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			buf = binary.AppendUvarint(buf, f.Uint())
+		case reflect.Bool:
+			if f.Bool() {
+				buf = append(buf, 1)
+			} else {
+				buf = append(buf, 0)
+			}
+		case reflect.Float32, reflect.Float64:
+			buf = binary.AppendUvarint(buf, math.Float64bits(f.Float()))
+		case reflect.String:
+			buf = binary.AppendUvarint(buf, uint64(f.Len()))
+			buf = append(buf, f.String()...)
+		case reflect.Struct:
+			if f.Type() == timeType {
+				buf = binary.AppendVarint(buf, f.Interface().(time.Time).Unix())
+				continue
+			}
+			var err error
+			if buf, err = appendStruct(buf, f); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("callbacks: unsupported field %s.%s (%s)", t.Name(), t.Field(i).Name, f.Kind())
+		}
+	}
+	return buf, nil
+}
+
+type decoder struct {
+	buf []byte
+}
+
+func (d *decoder) uvarint() (uint64, bool) {
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		return 0, false
+	}
+	d.buf = d.buf[n:]
+	return v, true
+}
+
+func (d *decoder) varint() (int64, bool) {
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		return 0, false
+	}
+	d.buf = d.buf[n:]
+	return v, true
+}
+
+func (d *decoder) readStruct(rv reflect.Value) bool {
+	t := rv.Type()
+	for i := 0; i < rv.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		f := rv.Field(i)
+		switch f.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v, ok := d.varint()
+			if !ok || f.OverflowInt(v) {
+				return false
+			}
+			f.SetInt(v)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v, ok := d.uvarint()
+			if !ok || f.OverflowUint(v) {
+				return false
+			}
+			f.SetUint(v)
+		case reflect.Bool:
+			if len(d.buf) == 0 || d.buf[0] > 1 {
+				return false
+			}
+			f.SetBool(d.buf[0] == 1)
+			d.buf = d.buf[1:]
+		case reflect.Float32, reflect.Float64:
+			v, ok := d.uvarint()
+			if !ok {
+				return false
+			}
+			f.SetFloat(math.Float64frombits(v))
+		case reflect.String:
+			n, ok := d.uvarint()
+			if !ok || n > uint64(len(d.buf)) {
+				return false
+			}
+			f.SetString(string(d.buf[:n]))
+			d.buf = d.buf[n:]
+		case reflect.Struct:
+			if f.Type() == timeType {
+				v, ok := d.varint()
+				if !ok {
+					return false
+				}
+				f.Set(reflect.ValueOf(time.Unix(v, 0)))
+				continue
+			}
+			if !d.readStruct(f) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
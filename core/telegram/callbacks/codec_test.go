@@ -0,0 +1,61 @@
+package callbacks
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type orderAction struct {
+	OrderID int64
+	Page    uint8
+	Confirm bool
+	Note    string
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	c := NewCodec(CodecOptions{Key: "secret"})
+	in := orderAction{OrderID: -123456789, Page: 3, Confirm: true, Note: "ok"}
+	data, err := c.Encode("order", 2, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > MaxDataLen {
+		t.Fatalf("encoded length %d", len(data))
+	}
+	var out orderAction
+	if err := c.Decode("order", data, 2, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Fatalf("decoded %+v, want %+v", out, in)
+	}
+}
+
+func TestCodecRejects(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c := NewCodec(CodecOptions{Key: "secret", MaxAge: time.Hour})
+	c.now = func() time.Time { return now }
+	data, err := c.Encode("order", 1, orderAction{OrderID: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out orderAction
+	if err := NewCodec(CodecOptions{Key: "other", MaxAge: time.Hour}).Decode("order", data, 1, &out); !errors.Is(err, ErrTampered) {
+		t.Errorf("wrong key: %v", err)
+	}
+	if err := c.Decode("refund", data, 1, &out); !errors.Is(err, ErrTampered) {
+		t.Errorf("other callback: %v", err)
+	}
+	if err := c.Decode("order", data, 2, &out); !errors.Is(err, ErrStale) {
+		t.Errorf("old version: %v", err)
+	}
+	now = now.Add(2 * time.Hour)
+	if err := c.Decode("order", data, 1, &out); !errors.Is(err, ErrStale) {
+		t.Errorf("expired: %v", err)
+	}
+	if err := c.Decode("order", "!!", 1, &out); !errors.Is(err, ErrMalformed) {
+		t.Errorf("garbage: %v", err)
+	}
+}
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS callback_payloads (
+    token        TEXT PRIMARY KEY,
+    callback_key TEXT NOT NULL,
+    payload      TEXT NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS callback_payloads_expires_at_idx ON callback_payloads (expires_at);
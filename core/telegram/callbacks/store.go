@@ -0,0 +1,172 @@
+package callbacks
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"embed"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TokenPrefix marks a callback payload that references a stored payload.
+const TokenPrefix = "~"
+
+// DefaultPayloadTTL is used when Put is called with a non-positive ttl.
+const DefaultPayloadTTL = 48 * time.Hour
+
+// PayloadStore keeps callback payloads that do not fit into callback_data.
+// Payloads are bound to the callback key they were minted for, so a token
+// cannot be replayed against another callback.
+type PayloadStore interface {
+	// Put saves payload for the callback key and returns a short token for it.
+	Put(ctx context.Context, key, payload string, ttl time.Duration) (string, error)
+	// Get returns the payload for token; ok is false when it is unknown,
+	// expired or was stored for another key.
+	Get(ctx context.Context, key, token string) (payload string, ok bool, err error)
+}
+
+// IsToken reports whether a callback payload is a stored-payload token.
+func IsToken(payload string) bool {
+	return strings.HasPrefix(payload, TokenPrefix)
+}
+
+// newToken returns TokenPrefix followed by 12 random base64url characters.
+func newToken() (string, error) {
+	b := make([]byte, 9)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return TokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+type memoryEntry struct {
+	key     string
+	payload string
+	expires time.Time
+}
+
+// MemoryStore keeps payloads in process memory; they are lost on restart.
+// Expired entries are swept lazily on Put.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates an empty in-memory PayloadStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]memoryEntry)}
+}
+
+// Put saves payload for key and ttl.
+func (s *MemoryStore) Put(_ context.Context, key, payload string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		ttl = DefaultPayloadTTL
+	}
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, e := range s.entries {
+			if now.After(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	s.entries[token] = memoryEntry{key: key, payload: payload, expires: now.Add(ttl)}
+	return token, nil
+}
+
+// Get returns a payload stored for key that has not expired.
+func (s *MemoryStore) Get(_ context.Context, key, token string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[token]
+	if !ok || e.key != key {
+		return "", false, nil
+	}
+	if time.Now().After(e.expires) {
+		delete(s.entries, token)
+		return "", false, nil
+	}
+	return e.payload, true, nil
+}
+
+// Migrations holds the golang-migrate files creating the SQLStore table,
+// for bots that copy them into their own migrations directory.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// SQLStore persists payloads in PostgreSQL. Create its table with
+// EnsureSchema or by applying Migrations. Expired rows are ignored by Get
+// and removed by Cleanup.
+type SQLStore struct {
+	DB *sqlx.DB
+}
+
+// EnsureSchema creates the callback_payloads table when it does not exist yet.
+func (s SQLStore) EnsureSchema(ctx context.Context) error {
+	schema, err := Migrations.ReadFile("migrations/000001_callback_payloads.up.sql")
+	if err != nil {
+		return fmt.Errorf("callbacks: read schema: %w", err)
+	}
+	if _, err := s.DB.ExecContext(ctx, string(schema)); err != nil {
+		return fmt.Errorf("callbacks: create schema: %w", err)
+	}
+	return nil
+}
+
+// Put saves payload for key and ttl.
+func (s SQLStore) Put(ctx context.Context, key, payload string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		ttl = DefaultPayloadTTL
+	}
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	_, err = s.DB.ExecContext(ctx,
+		`INSERT INTO callback_payloads (token, callback_key, payload, expires_at) VALUES ($1, $2, $3, $4)`,
+		token, key, payload, time.Now().Add(ttl))
+	if err != nil {
+		return "", fmt.Errorf("callbacks: store payload: %w", err)
+	}
+	return token, nil
+}
+
+// Get returns a payload stored for key that has not expired.
+func (s SQLStore) Get(ctx context.Context, key, token string) (string, bool, error) {
+	var payload string
+	err := s.DB.GetContext(ctx, &payload,
+		`SELECT payload FROM callback_payloads WHERE token = $1 AND callback_key = $2 AND expires_at > now()`,
+		token, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("callbacks: load payload: %w", err)
+	}
+	return payload, true, nil
+}
+
+// Cleanup deletes expired payloads and returns how many were removed.
+func (s SQLStore) Cleanup(ctx context.Context) (int64, error) {
+	res, err := s.DB.ExecContext(ctx, `DELETE FROM callback_payloads WHERE expires_at <= now()`)
+	if err != nil {
+		return 0, fmt.Errorf("callbacks: cleanup payloads: %w", err)
+	}
+	return res.RowsAffected()
+}
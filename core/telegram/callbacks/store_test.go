@@ -0,0 +1,25 @@
+package callbacks
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreBindsKey(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	token, err := s.Put(ctx, "order", "a long payload", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsToken(token) {
+		t.Fatalf("token %q lacks prefix", token)
+	}
+	if got, ok, _ := s.Get(ctx, "order", token); !ok || got != "a long payload" {
+		t.Fatalf("Get = %q, %v", got, ok)
+	}
+	if _, ok, _ := s.Get(ctx, "refund", token); ok {
+		t.Fatal("token resolved for another callback key")
+	}
+}
@@ -48,3 +48,16 @@ func CallbackPayload(c tele.Context) string {
 	_, payload := ParseCallbackData(cb)
 	return payload
 }
+
+// SetPayload replaces the payload in cb, keeping the encoding it arrived in.
+func SetPayload(cb *tele.Callback, payload string) {
+	if cb == nil {
+		return
+	}
+	if cb.Unique != "" {
+		cb.Data = payload
+		return
+	}
+	key, _ := ParseCallbackData(cb)
+	cb.Data = "\f" + key + "|" + payload
+}
@@ -0,0 +1,448 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// ArgType selects how a command argument is parsed.
+type ArgType int
+
+const (
+	// ArgString accepts any token.
+	ArgString ArgType = iota
+	// ArgInt accepts a base-10 integer.
+	ArgInt
+	// ArgFloat accepts a decimal number; a comma decimal separator is allowed.
+	ArgFloat
+	// ArgBool accepts true/false, yes/no, on/off and 1/0. As a flag it may be
+	// given without value; a following bool literal is taken as its value.
+	ArgBool
+	// ArgDuration accepts Go durations ("90s", "1h30m") plus a "d" suffix for days.
+	ArgDuration
+	// ArgDate accepts the formats understood by helpers.ParseFlexibleDate.
+	ArgDate
+	// ArgUser accepts a numeric user ID, an @username or a text mention.
+	ArgUser
+)
+
+func (t ArgType) String() string {
+	switch t {
+	case ArgInt:
+		return "int"
+	case ArgFloat:
+		return "number"
+	case ArgBool:
+		return "bool"
+	case ArgDuration:
+		return "duration"
+	case ArgDate:
+		return "date"
+	case ArgUser:
+		return "user"
+	}
+	return "text"
+}
+
+// Arg declares a single positional or flag-style (--name=value) argument.
+type Arg struct {
+	Name        string
+	Type        ArgType
+	Description string
+	Required    bool
+	// Flag makes the argument named: --name=value, --name value or --name for bools.
+	Flag bool
+	// Default is parsed like user input when the argument is omitted.
+	Default string
+	// Rest makes the last positional argument take the remaining text verbatim,
+	// up to the first known --flag.
+	Rest bool
+}
+
+// ArgError describes invalid user input for a command.
+type ArgError struct {
+	Arg    string
+	Reason string
+}
+
+func (e *ArgError) Error() string {
+	if e.Arg == "" {
+		return e.Reason
+	}
+	return fmt.Sprintf("%s: %s", e.Arg, e.Reason)
+}
+
+// Code implements the error code contract used in handler logs.
+func (e *ArgError) Code() string {
+	return "INVALID_ARGS"
+}
+
+// Values holds parsed command arguments keyed by Arg.Name.
+type Values struct {
+	values map[string]any
+}
+
+// Has reports whether the argument was provided or defaulted.
+func (v Values) Has(name string) bool {
+	_, ok := v.values[name]
+	return ok
+}
+
+// String returns a string argument.
+func (v Values) String(name string) string {
+	s, _ := v.values[name].(string)
+	return s
+}
+
+// Int returns an ArgInt argument.
+func (v Values) Int(name string) int64 {
+	n, _ := v.values[name].(int64)
+	return n
+}
+
+// Float returns an ArgFloat argument.
+func (v Values) Float(name string) float64 {
+	f, _ := v.values[name].(float64)
+	return f
+}
+
+// Bool returns an ArgBool argument.
+func (v Values) Bool(name string) bool {
+	b, _ := v.values[name].(bool)
+	return b
+}
+
+// Duration returns an ArgDuration argument.
+func (v Values) Duration(name string) time.Duration {
+	d, _ := v.values[name].(time.Duration)
+	return d
+}
+
+// Time returns an ArgDate argument.
+func (v Values) Time(name string) time.Time {
+	t, _ := v.values[name].(time.Time)
+	return t
+}
+
+// User returns an ArgUser argument. Only ID or Username may be set
+// depending on how the user was referenced.
+func (v Values) User(name string) *tele.User {
+	u, _ := v.values[name].(*tele.User)
+	return u
+}
+
+const argsKey = "command_args"
+
+// ArgsFrom returns arguments parsed for the current command, if any.
+func ArgsFrom(c tele.Context) Values {
+	v, _ := c.Get(argsKey).(Values)
+	return v
+}
+
+// ParseArgs parses the message payload against schema.
+func ParseArgs(c tele.Context, schema []Arg) (Values, error) {
+	var payload string
+	var msg *tele.Message
+	if msg = c.Message(); msg != nil {
+		payload = msg.Payload
+	}
+	return parseArgs(payload, msg, schema)
+}
+
+func parseArgs(payload string, msg *tele.Message, schema []Arg) (Values, error) {
+	vals := Values{values: make(map[string]any, len(schema))}
+	flags := make(map[string]Arg)
+	var positional []Arg
+	for _, a := range schema {
+		if a.Flag {
+			flags[a.Name] = a
+		} else {
+			positional = append(positional, a)
+		}
+	}
+
+	tokens := tokenize(payload)
+	isFlag := func(tok string) bool {
+		rest, ok := strings.CutPrefix(tok, "--")
+		name, _, _ := strings.Cut(rest, "=")
+		_, known := flags[name]
+		return ok && known
+	}
+	pos := 0
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i].text
+		if strings.HasPrefix(tok, "--") && len(tok) > 2 {
+			name, value, hasValue := strings.Cut(tok[2:], "=")
+			a, ok := flags[name]
+			if !ok {
+				return vals, &ArgError{Arg: name, Reason: "unknown option"}
+			}
+			if !hasValue {
+				switch {
+				case a.Type == ArgBool:
+					value = "true"
+					if i+1 < len(tokens) && isBoolLiteral(tokens[i+1].text) {
+						i++
+						value = tokens[i].text
+					}
+				case i+1 < len(tokens):
+					i++
+					value = tokens[i].text
+				default:
+					return vals, &ArgError{Arg: name, Reason: "missing value"}
+				}
+			}
+			parsed, err := parseValue(a, value, msg)
+			if err != nil {
+				return vals, err
+			}
+			vals.values[name] = parsed
+			continue
+		}
+		if pos >= len(positional) {
+			return vals, &ArgError{Reason: "too many arguments"}
+		}
+		a := positional[pos]
+		pos++
+		if a.Rest {
+			end := len(tokens)
+			for j := i + 1; j < len(tokens); j++ {
+				if isFlag(tokens[j].text) {
+					end = j
+					break
+				}
+			}
+			tok = payload[tokens[i].start:tokens[end-1].end]
+			i = end - 1
+		}
+		parsed, err := parseValue(a, tok, msg)
+		if err != nil {
+			return vals, err
+		}
+		vals.values[a.Name] = parsed
+	}
+
+	for _, a := range schema {
+		if _, ok := vals.values[a.Name]; ok {
+			continue
+		}
+		if a.Default != "" {
+			parsed, err := parseValue(a, a.Default, msg)
+			if err != nil {
+				return vals, err
+			}
+			vals.values[a.Name] = parsed
+			continue
+		}
+		if a.Required {
+			return vals, &ArgError{Arg: a.Name, Reason: "required"}
+		}
+	}
+	return vals, nil
+}
+
+func parseValue(a Arg, raw string, msg *tele.Message) (any, error) {
+	invalid := func() error {
+		return &ArgError{Arg: a.Name, Reason: fmt.Sprintf("expected %s, got %q", a.Type, raw)}
+	}
+	switch a.Type {
+	case ArgInt:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, invalid()
+		}
+		return n, nil
+	case ArgFloat:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(raw, ",", "."), 64)
+		if err != nil {
+			return nil, invalid()
+		}
+		return f, nil
+	case ArgBool:
+		if b, ok := parseBool(raw); ok {
+			return b, nil
+		}
+		return nil, invalid()
+	case ArgDuration:
+		d, err := parseDuration(raw)
+		if err != nil {
+			return nil, invalid()
+		}
+		return d, nil
+	case ArgDate:
+		t, ok := tghelpers.ParseFlexibleDate(raw)
+		if !ok {
+			return nil, invalid()
+		}
+		return t, nil
+	case ArgUser:
+		if u := parseUser(raw, msg); u != nil {
+			return u, nil
+		}
+		return nil, invalid()
+	}
+	return raw, nil
+}
+
+func parseBool(raw string) (value, ok bool) {
+	switch strings.ToLower(raw) {
+	case "true", "yes", "on", "1":
+		return true, true
+	case "false", "no", "off", "0":
+		return false, true
+	}
+	return false, false
+}
+
+func isBoolLiteral(raw string) bool {
+	_, ok := parseBool(raw)
+	return ok
+}
+
+func parseDuration(raw string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(raw)
+}
+
+func parseUser(raw string, msg *tele.Message) *tele.User {
+	if id, err := strconv.ParseInt(raw, 10, 64); err == nil && id != 0 {
+		return &tele.User{ID: id}
+	}
+	if msg != nil {
+		for _, e := range msg.Entities {
+			if e.Type == tele.EntityTMention && e.User != nil && msg.EntityText(e) == raw {
+				return e.User
+			}
+		}
+	}
+	if name, ok := strings.CutPrefix(raw, "@"); ok && name != "" {
+		return &tele.User{Username: name}
+	}
+	return nil
+}
+
+// token is an unquoted argument and its byte span in the payload.
+type token struct {
+	text       string
+	start, end int
+}
+
+// tokenize splits on whitespace while keeping "double quoted" segments together.
+func tokenize(s string) []token {
+	var (
+		out     []token
+		cur     strings.Builder
+		quoted  bool
+		started bool
+		start   int
+	)
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			if !started {
+				start = i
+			}
+			started = true
+		case unicode.IsSpace(r) && !quoted:
+			if started {
+				out = append(out, token{cur.String(), start, i})
+				cur.Reset()
+				started = false
+			}
+		default:
+			if !started {
+				start = i
+			}
+			cur.WriteRune(r)
+			started = true
+		}
+	}
+	if started {
+		out = append(out, token{cur.String(), start, len(s)})
+	}
+	return out
+}
+
+// Usage renders a one-line usage string such as "/remind <when:duration> [text...] [--silent]".
+func Usage(name string, schema []Arg) string {
+	var b strings.Builder
+	b.WriteString(name)
+	for _, a := range schema {
+		b.WriteByte(' ')
+		label := a.Name
+		if a.Type != ArgString {
+			label += ":" + a.Type.String()
+		}
+		if a.Rest {
+			label += "..."
+		}
+		if a.Flag {
+			if a.Type == ArgBool {
+				label = "--" + a.Name
+			} else {
+				label = "--" + a.Name + "=" + a.Type.String()
+			}
+		}
+		if a.Required {
+			b.WriteString("<" + label + ">")
+		} else {
+			b.WriteString("[" + label + "]")
+		}
+	}
+	return b.String()
+}
+
+// Help renders usage followed by one line per documented argument.
+func Help(name string, cmd Command) string {
+	var b strings.Builder
+	b.WriteString(Usage(name, cmd.Args))
+	if cmd.Description != "" {
+		b.WriteString("\n" + cmd.Description)
+	}
+	for _, a := range cmd.Args {
+		if a.Description == "" {
+			continue
+		}
+		label := a.Name
+		if a.Flag {
+			label = "--" + a.Name
+		}
+		b.WriteString("\n  " + label + " — " + a.Description)
+		if a.Default != "" {
+			b.WriteString(" (default " + a.Default + ")")
+		}
+	}
+	return b.String()
+}
+
+// Bind builds a tele.HandlerFunc that parses arguments against cmd.Args,
+// replies with usage on invalid input and otherwise invokes cmd.Run.
+func Bind(name string, cmd Command) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		vals, err := ParseArgs(c, cmd.Args)
+		if err != nil {
+			text := i18n.T(c, i18n.KeyArgsInvalid, i18n.Args{
+				"error": err.Error(),
+				"usage": Usage(name, cmd.Args),
+			})
+			return tghelpers.SendText(c, text)
+		}
+		c.Set(argsKey, vals)
+		return cmd.Run(c, vals)
+	}
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseArgs(t *testing.T) {
+	schema := []Arg{
+		{Name: "count", Type: ArgInt, Required: true},
+		{Name: "every", Type: ArgDuration, Default: "1h"},
+		{Name: "silent", Type: ArgBool, Flag: true},
+		{Name: "who", Type: ArgUser, Flag: true},
+		{Name: "note", Rest: true},
+	}
+
+	vals, err := parseArgs(`3 2d --silent --who=@alice "buy milk" today`, nil, schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := vals.Int("count"); got != 3 {
+		t.Errorf("count = %d", got)
+	}
+	if got := vals.Duration("every"); got != 48*time.Hour {
+		t.Errorf("every = %s", got)
+	}
+	if !vals.Bool("silent") {
+		t.Error("silent flag not set")
+	}
+	if u := vals.User("who"); u == nil || u.Username != "alice" {
+		t.Errorf("who = %+v", u)
+	}
+	if got := vals.String("note"); got != `"buy milk" today` {
+		t.Errorf("note = %q", got)
+	}
+
+	vals, err = parseArgs("1 2h --silent false call  mom --no-such  --who=42", nil, schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if vals.Bool("silent") || !vals.Has("silent") {
+		t.Error("--silent false not taken as the flag value")
+	}
+	if got := vals.String("note"); got != "call  mom --no-such" {
+		t.Errorf("note = %q", got)
+	}
+	if u := vals.User("who"); u == nil || u.ID != 42 {
+		t.Errorf("flag after rest = %+v", u)
+	}
+
+	vals, err = parseArgs("5", nil, schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := vals.Duration("every"); got != time.Hour {
+		t.Errorf("default every = %s", got)
+	}
+}
+
+func TestParseArgsErrors(t *testing.T) {
+	schema := []Arg{
+		{Name: "id", Type: ArgInt, Required: true},
+		{Name: "on", Type: ArgDate, Flag: true},
+	}
+	tests := []struct {
+		payload string
+		arg     string
+	}{
+		{"", "id"},
+		{"abc", "id"},
+		{"1 2", ""},
+		{"1 --on=someday", "on"},
+		{"1 --unknown", "unknown"},
+	}
+	for _, tt := range tests {
+		_, err := parseArgs(tt.payload, nil, schema)
+		var argErr *ArgError
+		if !errors.As(err, &argErr) {
+			t.Fatalf("parseArgs(%q) error = %v, want ArgError", tt.payload, err)
+		}
+		if argErr.Arg != tt.arg {
+			t.Errorf("parseArgs(%q) arg = %q, want %q", tt.payload, argErr.Arg, tt.arg)
+		}
+	}
+}
+
+func TestUsage(t *testing.T) {
+	schema := []Arg{
+		{Name: "id", Type: ArgInt, Required: true},
+		{Name: "reason", Rest: true},
+		{Name: "silent", Type: ArgBool, Flag: true},
+	}
+	want := "/ban <id:int> [reason...] [--silent]"
+	if got := Usage("/ban", schema); got != want {
+		t.Fatalf("Usage = %q, want %q", got, want)
+	}
+}
@@ -1,14 +1,52 @@
 package commands
 
 import (
+	"github.com/m3rciful/gobot/core/telegram/roles"
+
 	tele "gopkg.in/telebot.v4"
 )
 
+// ChatScope limits the chat types whose command menu lists a command.
+type ChatScope int
+
+const (
+	// ChatsAll shows the command in every chat type.
+	ChatsAll ChatScope = iota
+	// ChatsPrivate shows the command only in private chats with the bot.
+	ChatsPrivate
+	// ChatsGroups shows the command only in groups and supergroups.
+	ChatsGroups
+)
+
 // Command represents a bot command with its handler, description, and metadata.
+// DescriptionKey names the i18n catalog entry used for localized menus;
+// when empty, "commands.<name>" is looked up and Description is the fallback.
 type Command struct {
-	Handler     tele.HandlerFunc
-	Description string
-	AdminOnly   bool
+	Handler        tele.HandlerFunc
+	Description    string
+	DescriptionKey string
+	AdminOnly      bool
+	// Permissions must all be granted to the sender; AdminOnly implies roles.PermissionAll.
+	Permissions []roles.Permission
 	Hidden      bool
 	Aliases     []string
+	// Category groups the command in generated help; empty means general.
+	Category string
+	// Chats selects the menu scopes the command is published to.
+	Chats ChatScope
+	// Middleware runs after the global chain, access checks and registry
+	// middleware, just before Handler; the first entry runs outermost.
+	Middleware []tele.MiddlewareFunc
+
+	// Args declares the argument schema used for parsing, validation and help.
+	Args []Arg
+	// Run is a typed alternative to Handler: when Handler is nil the registry
+	// binds Run with Bind so it receives validated arguments.
+	Run func(c tele.Context, args Values) error
+
+	// Subcommands are dispatched by the first argument, e.g. "/admin users ban 1"
+	// walks admin → users → ban. Keys have no slash. Handler or Run, when set,
+	// handles input that matches no sub-command. AdminOnly and Permissions of a
+	// sub-command are checked with the registry authorizer (Registry.SetAuthorizer).
+	Subcommands map[string]Command
 }
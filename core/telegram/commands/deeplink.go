@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// MaxStartPayload is the longest payload Telegram accepts in a start link.
+const MaxStartPayload = 64
+
+// signatureLen is the encoded length of the truncated HMAC appended by LinkSigner.
+const signatureLen = 11
+
+// ErrInvalidStartPayload reports a payload that does not fit in a start link.
+var ErrInvalidStartPayload = errors.New("start payload must be 1-64 characters of A-Z, a-z, 0-9, _ and -")
+
+// StartPayload is the argument of a "/start <payload>" deep link.
+// "ref_123" yields Kind "ref" and Value "123"; a payload without "_" has an empty Kind.
+type StartPayload struct {
+	Raw    string
+	Kind   string
+	Value  string
+	Signed bool
+}
+
+// Int parses Value as a base-10 integer.
+func (p StartPayload) Int() (int64, error) {
+	return strconv.ParseInt(p.Value, 10, 64)
+}
+
+// ParseStartPayload splits raw into kind and value.
+func ParseStartPayload(raw string) StartPayload {
+	p := StartPayload{Raw: raw, Value: raw}
+	if kind, value, ok := strings.Cut(raw, "_"); ok {
+		p.Kind, p.Value = kind, value
+	}
+	return p
+}
+
+// StartPayloadFrom returns the deep-link payload of a "/start" message. With a
+// signer the payload must carry a valid signature, which is stripped.
+func StartPayloadFrom(c tele.Context, signer *LinkSigner) (StartPayload, bool) {
+	inv, ok := InvocationFrom(c)
+	if !ok {
+		if inv, ok = Invoke(c); !ok {
+			return StartPayload{}, false
+		}
+	}
+	if inv.Name != "/start" || inv.Args == "" {
+		return StartPayload{}, false
+	}
+	raw := inv.Args
+	if signer != nil {
+		if raw, ok = signer.Verify(raw); !ok {
+			return StartPayload{}, false
+		}
+	}
+	p := ParseStartPayload(raw)
+	p.Signed = signer != nil
+	return p, true
+}
+
+// StartLink builds https://t.me/<bot>?start=<payload>.
+func StartLink(botUsername, payload string) (string, error) {
+	if !validStartPayload(payload) {
+		return "", ErrInvalidStartPayload
+	}
+	return "https://t.me/" + strings.TrimPrefix(botUsername, "@") + "?start=" + payload, nil
+}
+
+func validStartPayload(s string) bool {
+	if s == "" || len(s) > MaxStartPayload {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		if b != '_' && b != '-' && (b < '0' || b > '9') && (b < 'a' || b > 'z') && (b < 'A' || b > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// LinkSigner appends a truncated HMAC-SHA256 to start payloads so handlers can
+// trust values such as referrer IDs. Signing adds 12 characters, leaving 52 for
+// the payload itself.
+type LinkSigner struct {
+	key []byte
+}
+
+// NewLinkSigner creates a signer for key (usually telegram.signing_key).
+func NewLinkSigner(key string) *LinkSigner {
+	return &LinkSigner{key: []byte(key)}
+}
+
+// Sign returns payload followed by "-" and its signature.
+func (s *LinkSigner) Sign(payload string) string {
+	return payload + "-" + s.mac(payload)
+}
+
+// Verify checks a signed payload and returns it without the signature.
+func (s *LinkSigner) Verify(signed string) (string, bool) {
+	cut := len(signed) - signatureLen - 1
+	if cut < 1 || signed[cut] != '-' {
+		return "", false
+	}
+	payload := signed[:cut]
+	if !hmac.Equal([]byte(signed[cut+1:]), []byte(s.mac(payload))) {
+		return "", false
+	}
+	return payload, true
+}
+
+// StartLink builds a start link carrying the signed payload.
+func (s *LinkSigner) StartLink(botUsername, payload string) (string, error) {
+	return StartLink(botUsername, s.Sign(payload))
+}
+
+func (s *LinkSigner) mac(payload string) string {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:8])
+}
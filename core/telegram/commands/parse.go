@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"strings"
+	"unicode"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Invocation is a parsed "/command[@bot] args" message.
+type Invocation struct {
+	// Name is the lowercased command with its slash, e.g. "/stats".
+	Name string
+	// Bot is the addressed bot username without "@", empty when not mentioned.
+	Bot string
+	// Args is the trimmed text after the command.
+	Args string
+}
+
+// ParseCommand splits text into an Invocation. It reports false when text is
+// not a command or is addressed to a bot other than botUsername; a mention is
+// accepted for any bot when botUsername is empty.
+func ParseCommand(text, botUsername string) (Invocation, bool) {
+	if len(text) < 2 || text[0] != '/' {
+		return Invocation{}, false
+	}
+	head, args := text, ""
+	if i := strings.IndexFunc(text, unicode.IsSpace); i >= 0 {
+		head, args = text[:i], text[i:]
+	}
+	name, bot, _ := strings.Cut(head[1:], "@")
+	if name == "" || !isCommandName(name) {
+		return Invocation{}, false
+	}
+	if bot != "" && botUsername != "" && !strings.EqualFold(bot, strings.TrimPrefix(botUsername, "@")) {
+		return Invocation{}, false
+	}
+	return Invocation{
+		Name: "/" + strings.ToLower(name),
+		Bot:  bot,
+		Args: strings.TrimSpace(args),
+	}, true
+}
+
+func isCommandName(name string) bool {
+	for _, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+const invocationKey = "command_invocation"
+
+// Invoke parses the current message as a command addressed to this bot,
+// stores the result for InvocationFrom and sets msg.Payload to its arguments.
+func Invoke(c tele.Context) (Invocation, bool) {
+	msg := c.Message()
+	if msg == nil {
+		return Invocation{}, false
+	}
+	inv, ok := ParseCommand(msg.Text, BotUsername(c))
+	if !ok {
+		return Invocation{}, false
+	}
+	msg.Payload = inv.Args
+	c.Set(invocationKey, inv)
+	return inv, true
+}
+
+// InvocationFrom returns the command parsed for the current update, if any.
+func InvocationFrom(c tele.Context) (Invocation, bool) {
+	inv, ok := c.Get(invocationKey).(Invocation)
+	return inv, ok
+}
+
+// BotUsername returns the username of the bot handling c, or "" when unknown.
+func BotUsername(c tele.Context) string {
+	if b, ok := c.Bot().(*tele.Bot); ok && b.Me != nil {
+		return b.Me.Username
+	}
+	return ""
+}
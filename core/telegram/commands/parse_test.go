@@ -0,0 +1,49 @@
+package commands
+
+import "testing"
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		text string
+		ok   bool
+		name string
+		args string
+	}{
+		{"/stats", true, "/stats", ""},
+		{"/Stats@MyBot  week  ", true, "/stats", "week"},
+		{"/start ref_123", true, "/start", "ref_123"},
+		{"/stats@OtherBot", false, "", ""},
+		{"/usr/bin", false, "", ""},
+		{"hello", false, "", ""},
+	}
+	for _, tt := range tests {
+		inv, ok := ParseCommand(tt.text, "mybot")
+		if ok != tt.ok || inv.Name != tt.name || inv.Args != tt.args {
+			t.Errorf("ParseCommand(%q) = %+v, %v", tt.text, inv, ok)
+		}
+	}
+}
+
+func TestLinkSigner(t *testing.T) {
+	s := NewLinkSigner("secret")
+	signed := s.Sign("ref_42")
+	if payload, ok := s.Verify(signed); !ok || payload != "ref_42" {
+		t.Fatalf("Verify(%q) = %q, %v", signed, payload, ok)
+	}
+	if _, ok := s.Verify("ref_43" + signed[len("ref_42"):]); ok {
+		t.Fatal("tampered payload verified")
+	}
+	if _, ok := NewLinkSigner("other").Verify(signed); ok {
+		t.Fatal("payload verified with a different key")
+	}
+	link, err := s.StartLink("@mybot", "ref_42")
+	if err != nil || link != "https://t.me/mybot?start="+signed {
+		t.Fatalf("StartLink = %q, %v", link, err)
+	}
+	if _, err := StartLink("mybot", "bad payload"); err == nil {
+		t.Fatal("expected ErrInvalidStartPayload")
+	}
+	if p := ParseStartPayload("ref_42"); p.Kind != "ref" || p.Value != "42" {
+		t.Fatalf("ParseStartPayload = %+v", p)
+	}
+}
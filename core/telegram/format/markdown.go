@@ -2,7 +2,7 @@ package format
 
 import (
 	"fmt"
-	"regexp"
+	"strings"
 )
 
 const (
@@ -12,17 +12,90 @@ const (
 	MarkdownV2 = 2
 )
 
-const mdV2Specials = "_*[]()~`>#+-=|{}.!"
+const (
+	// EntityPre marks text placed inside a ```pre``` block.
+	EntityPre = "pre"
+	// EntityCode marks text placed inside an `inline code` span.
+	EntityCode = "code"
+	// EntityTextLink marks the URL part of an inline link: [text](url).
+	EntityTextLink = "text_link"
+	// EntityCustomEmoji marks the URL part of a custom emoji: ![👍](tg://emoji?id=...).
+	EntityCustomEmoji = "custom_emoji"
+)
+
+const (
+	mdV1Specials     = "_*`["
+	mdV2Specials     = "_*[]()~`>#+-=|{}.!\\"
+	mdV2CodeSpecials = "`\\"
+	mdV2LinkSpecials = ")\\"
+)
+
+var (
+	mdV1Table     = specialsTable(mdV1Specials)
+	mdV2Table     = specialsTable(mdV2Specials)
+	mdV2CodeTable = specialsTable(mdV2CodeSpecials)
+	mdV2LinkTable = specialsTable(mdV2LinkSpecials)
+)
+
+func specialsTable(chars string) *[128]bool {
+	var t [128]bool
+	for i := 0; i < len(chars); i++ {
+		t[chars[i]] = true
+	}
+	return &t
+}
 
 // EscapeMarkdown escapes special characters for MarkdownV1 or V2.
+// entityType selects the escaping context as defined by the Telegram Bot API:
+// EntityPre/EntityCode for code blocks, EntityTextLink/EntityCustomEmoji for
+// the URL part of links, and anything else (empty, "bold", "spoiler", ...) for
+// regular text. MarkdownV1 does not allow escaping inside code and links, so
+// text in those contexts is returned unchanged for that version.
 func EscapeMarkdown(text string, version int, entityType string) (string, error) {
 	switch version {
 	case MarkdownV1:
-		re := regexp.MustCompile(`([_*\\\[` + "`" + `])`)
-		return re.ReplaceAllString(text, `\\$1`), nil
+		switch entityType {
+		case EntityPre, EntityCode, EntityTextLink, EntityCustomEmoji:
+			return text, nil
+		default:
+			return escapeWith(text, mdV1Table), nil
+		}
 	case MarkdownV2:
-		re := regexp.MustCompile("[" + regexp.QuoteMeta(mdV2Specials) + "]")
-		return re.ReplaceAllString(text, `\\$1`), nil
+		switch entityType {
+		case EntityPre, EntityCode:
+			return escapeWith(text, mdV2CodeTable), nil
+		case EntityTextLink, EntityCustomEmoji:
+			return escapeWith(text, mdV2LinkTable), nil
+		default:
+			return escapeWith(text, mdV2Table), nil
+		}
+	default:
+		return "", fmt.Errorf("unsupported markdown version: %d", version)
+	}
+}
+
+// escapeWith prefixes every character flagged in table with a backslash.
+// Text without special characters is returned as-is without allocating.
+func escapeWith(text string, table *[128]bool) string {
+	n := 0
+	for i := 0; i < len(text); i++ {
+		if c := text[i]; c < 128 && table[c] {
+			n++
+		}
+	}
+	if n == 0 {
+		return text
+	}
+	var b strings.Builder
+	b.Grow(len(text) + n)
+	start := 0
+	for i := 0; i < len(text); i++ {
+		if c := text[i]; c < 128 && table[c] {
+			b.WriteString(text[start:i])
+			b.WriteByte('\\')
+			start = i
+		}
 	}
-	return "", fmt.Errorf("unsupported markdown version: %d", version)
+	b.WriteString(text[start:])
+	return b.String()
 }
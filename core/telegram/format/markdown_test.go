@@ -0,0 +1,60 @@
+package format
+
+import "testing"
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		version int
+		entity  string
+		want    string
+	}{
+		{"v2 plain passthrough", "hello world", MarkdownV2, "", "hello world"},
+		{"v2 unicode passthrough", "привет 👋", MarkdownV2, "", "привет 👋"},
+		{"v2 all specials", "_*[]()~`>#+-=|{}.!", MarkdownV2, "", `\_\*\[\]\(\)\~\` + "`" + `\>\#\+\-\=\|\{\}\.\!`},
+		{"v2 backslash", `a\b`, MarkdownV2, "", `a\\b`},
+		{"v2 sentence", "Total: 1.5 (approx)!", MarkdownV2, "", `Total: 1\.5 \(approx\)\!`},
+		{"v2 mixed unicode", "цена_1.0", MarkdownV2, "", `цена\_1\.0`},
+		{"v2 pre keeps specials", "a_b*c.d", MarkdownV2, EntityPre, "a_b*c.d"},
+		{"v2 pre escapes backtick", "x := `y`", MarkdownV2, EntityPre, "x := \\`y\\`"},
+		{"v2 code escapes backslash", `C:\tmp`, MarkdownV2, EntityCode, `C:\\tmp`},
+		{"v2 link url", "https://example.com/a_(b)", MarkdownV2, EntityTextLink, `https://example.com/a_(b\)`},
+		{"v2 link backslash", `https://x.io/\`, MarkdownV2, EntityTextLink, `https://x.io/\\`},
+		{"v2 custom emoji", "tg://emoji?id=5368324170671202286", MarkdownV2, EntityCustomEmoji, "tg://emoji?id=5368324170671202286"},
+		{"v1 specials", "snake_case *bold* `code` [link]", MarkdownV1, "", "snake\\_case \\*bold\\* \\`code\\` \\[link]"},
+		{"v1 untouched v2 specials", "1.5 (a)!", MarkdownV1, "", "1.5 (a)!"},
+		{"v1 inside entity", "a_b", MarkdownV1, EntityCode, "a_b"},
+		{"v2 bold as text", "a_b.c", MarkdownV2, "bold", `a\_b\.c`},
+		{"v2 spoiler as text", "(hidden)!", MarkdownV2, "spoiler", `\(hidden\)\!`},
+		{"v1 bold as text", "a_b", MarkdownV1, "bold", `a\_b`},
+		{"empty", "", MarkdownV2, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EscapeMarkdown(tt.text, tt.version, tt.entity)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("EscapeMarkdown(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeMarkdownErrors(t *testing.T) {
+	if _, err := EscapeMarkdown("x", 3, ""); err == nil {
+		t.Fatal("expected error for unsupported version")
+	}
+}
+
+func TestEscapeMarkdownNoAlloc(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = EscapeMarkdown("plain text without specials", MarkdownV2, "")
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations for clean text, got %v", allocs)
+	}
+}
@@ -0,0 +1,159 @@
+package telegram
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/m3rciful/gobot/core/telegram/commands"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
+	"github.com/m3rciful/gobot/core/telegram/middleware"
+	"github.com/m3rciful/gobot/core/telegram/roles"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Group bundles related commands under a shared category, access rules and middleware.
+type Group struct {
+	// Category is assigned to member commands that do not set their own.
+	Category string
+	// AdminOnly and Permissions are added to every member command.
+	AdminOnly   bool
+	Permissions []roles.Permission
+	// Middleware is prepended to the Middleware of every member command.
+	Middleware []tele.MiddlewareFunc
+}
+
+// RegisterGroup registers cmds (keyed by "/name") as members of g.
+func (r *Registry) RegisterGroup(g Group, cmds map[string]commands.Command) {
+	if r == nil {
+		return
+	}
+	names := make([]string, 0, len(cmds))
+	for name := range cmds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmd := cmds[name]
+		if cmd.Category == "" {
+			cmd.Category = g.Category
+		}
+		cmd.AdminOnly = cmd.AdminOnly || g.AdminOnly
+		if len(g.Permissions) > 0 {
+			cmd.Permissions = append(append([]roles.Permission(nil), g.Permissions...), cmd.Permissions...)
+		}
+		if len(g.Middleware) > 0 {
+			cmd.Middleware = append(append([]tele.MiddlewareFunc(nil), g.Middleware...), cmd.Middleware...)
+		}
+		r.registerCommand(name, cmd)
+	}
+}
+
+// subcommandRouter returns a handler that dispatches on the first payload
+// token to cmd.Subcommands, recursing for nested groups. Sub-command access
+// rules are checked with the registry authorizer at call time.
+func (r *Registry) subcommandRouter(path string, cmd commands.Command) tele.HandlerFunc {
+	handlers := make(map[string]tele.HandlerFunc, len(cmd.Subcommands))
+	for name, sub := range cmd.Subcommands {
+		subPath := path + " " + name
+		var h tele.HandlerFunc
+		switch {
+		case len(sub.Subcommands) > 0:
+			h = r.subcommandRouter(subPath, sub)
+		case sub.Handler != nil:
+			h = sub.Handler
+		case sub.Run != nil:
+			h = commands.Bind(subPath, sub)
+		default:
+			continue
+		}
+		handlers[name] = r.guardSubcommand(sub, middleware.Chain(h, sub.Middleware...))
+	}
+
+	fallback := cmd.Handler
+	if fallback == nil && cmd.Run != nil {
+		fallback = commands.Bind(path, cmd)
+	}
+
+	return func(c tele.Context) error {
+		if msg := c.Message(); msg != nil {
+			head, rest, _ := strings.Cut(strings.TrimSpace(msg.Payload), " ")
+			if name, _, ok := lookupSubcommand(cmd.Subcommands, head); ok {
+				if h, ok := handlers[name]; ok {
+					msg.Payload = strings.TrimSpace(rest)
+					tghelpers.WithHandler(c, strings.ReplaceAll(strings.TrimPrefix(path, "/")+"_"+name, " ", "_"))
+					return h(c)
+				}
+			}
+		}
+		if fallback != nil {
+			return fallback(c)
+		}
+		return tghelpers.SendText(c, r.subcommandsHelp(c, path, cmd))
+	}
+}
+
+func (r *Registry) guardSubcommand(sub commands.Command, h tele.HandlerFunc) tele.HandlerFunc {
+	if guard := r.Guard(sub.AdminOnly, sub.Permissions); guard != nil {
+		return guard(h)
+	}
+	return h
+}
+
+// normalizeSubcommands lowercases sub-command names at every level so
+// lookupSubcommand can match them case-insensitively.
+func normalizeSubcommands(subs map[string]commands.Command) map[string]commands.Command {
+	out := make(map[string]commands.Command, len(subs))
+	for name, sub := range subs {
+		if len(sub.Subcommands) > 0 {
+			sub.Subcommands = normalizeSubcommands(sub.Subcommands)
+		}
+		out[strings.ToLower(strings.TrimSpace(name))] = sub
+	}
+	return out
+}
+
+// lookupSubcommand finds a sub-command by name or alias (case-insensitive).
+func lookupSubcommand(subs map[string]commands.Command, token string) (string, commands.Command, bool) {
+	token = strings.ToLower(strings.TrimSpace(token))
+	if token == "" {
+		return "", commands.Command{}, false
+	}
+	if sub, ok := subs[token]; ok {
+		return token, sub, true
+	}
+	for name, sub := range subs {
+		for _, alias := range sub.Aliases {
+			if strings.EqualFold(alias, token) {
+				return name, sub, true
+			}
+		}
+	}
+	return "", commands.Command{}, false
+}
+
+// subcommandsHelp lists the sub-commands of cmd available at path.
+func (r *Registry) subcommandsHelp(c tele.Context, path string, cmd commands.Command) string {
+	names := make([]string, 0, len(cmd.Subcommands))
+	for name, sub := range cmd.Subcommands {
+		if !sub.Hidden {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(path + " <" + strings.Join(names, "|") + ">")
+	for _, name := range names {
+		sub := cmd.Subcommands[name]
+		desc := sub.Description
+		if sub.DescriptionKey != "" {
+			if text := i18n.T(c, sub.DescriptionKey); text != sub.DescriptionKey {
+				desc = text
+			}
+		}
+		b.WriteString("\n  " + name + " — " + desc)
+	}
+	return b.String()
+}
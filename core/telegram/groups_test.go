@@ -0,0 +1,63 @@
+package telegram
+
+import (
+	"testing"
+
+	"github.com/m3rciful/gobot/core/telegram/commands"
+	"github.com/m3rciful/gobot/core/telegram/middleware"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func commandContext(t *testing.T, userID int64, text, payload string) tele.Context {
+	t.Helper()
+	bot, err := tele.NewBot(tele.Settings{Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bot.NewContext(tele.Update{Message: &tele.Message{
+		Sender:  &tele.User{ID: userID},
+		Chat:    &tele.Chat{ID: userID, Type: tele.ChatPrivate},
+		Text:    text,
+		Payload: payload,
+	}})
+}
+
+func TestSubcommandAdminFallback(t *testing.T) {
+	var ran, rejected int
+	r := NewRegistry()
+	r.RegisterCommand("/admin", commands.Command{
+		Description: "Admin tools",
+		Subcommands: map[string]commands.Command{
+			"Users": {
+				Description: "Manage users",
+				AdminOnly:   true,
+				Handler: func(tele.Context) error {
+					ran++
+					return nil
+				},
+			},
+		},
+	})
+	r.SetAdminOptions(middleware.AdminOptions{
+		AdminID: 1,
+		OnReject: func(tele.Context) error {
+			rejected++
+			return nil
+		},
+	})
+	_, cmd, ok := r.LookupCommand("/admin")
+	if !ok {
+		t.Fatal("command not registered")
+	}
+
+	if err := cmd.Handler(commandContext(t, 1, "/admin users", "users")); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Handler(commandContext(t, 2, "/admin USERS", "USERS")); err != nil {
+		t.Fatal(err)
+	}
+	if ran != 1 || rejected != 1 {
+		t.Fatalf("ran = %d, rejected = %d; want 1 and 1", ran, rejected)
+	}
+}
@@ -0,0 +1,42 @@
+package telegram
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Heartbeat records when the bot last heard from Telegram: a successful
+// getUpdates call in polling mode or an accepted webhook request.
+type Heartbeat struct {
+	last atomic.Int64
+}
+
+// Beat records activity now.
+func (h *Heartbeat) Beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+// Last returns the time of the latest activity, or zero if there was none.
+func (h *Heartbeat) Last() time.Time {
+	ns := h.last.Load()
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// pollProbe beats the heartbeat on every successful getUpdates response.
+type pollProbe struct {
+	base      http.RoundTripper
+	heartbeat *Heartbeat
+}
+
+func (p *pollProbe) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := p.base.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusOK && strings.HasSuffix(req.URL.Path, "/getUpdates") {
+		p.heartbeat.Beat()
+	}
+	return resp, err
+}
@@ -0,0 +1,235 @@
+package telegram
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+	"github.com/m3rciful/gobot/core/telegram/commands"
+	"github.com/m3rciful/gobot/core/telegram/format"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
+	"github.com/m3rciful/gobot/core/telegram/roles"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	defaultHelpCommand  = "/help"
+	defaultHelpPageSize = 15
+	helpCallbackKey     = "help"
+)
+
+// HelpOptions configures the built-in /help command.
+type HelpOptions struct {
+	// Command defaults to "/help".
+	Command string
+	// Description defaults to the localized core.help.description message.
+	Description string
+	// PageSize bounds the number of commands per page (default 15).
+	PageSize int
+	// Authorizer decides visibility of AdminOnly and permission-guarded commands.
+	// Defaults to the registry authorizer; without one AdminOnly commands are
+	// shown only to AdminIDs, which default to the registry admin options
+	// (see SetAdminOptions and CommandRoutes).
+	Authorizer *roles.Authorizer
+	AdminIDs   []int64
+}
+
+type helpEntry struct {
+	name string
+	cmd  commands.Command
+}
+
+// EnableHelp registers a /help command that lists the commands available to
+// the caller, grouped by Category and paginated with inline buttons, and
+// shows detailed usage for "/help <command>". The listing is rendered from
+// the registry on every call, so it always matches ListCommands.
+func (r *Registry) EnableHelp(opts HelpOptions) {
+	if r == nil {
+		return
+	}
+	if opts.Command == "" {
+		opts.Command = defaultHelpCommand
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultHelpPageSize
+	}
+	desc := opts.Description
+	if desc == "" {
+		bundle := i18n.CurrentBundle()
+		desc = bundle.Translate(bundle.DefaultLocale(), i18n.KeyHelpDescription)
+	}
+
+	r.RegisterCommand(opts.Command, commands.Command{
+		Description:    desc,
+		DescriptionKey: i18n.KeyHelpDescription,
+		Args:           []commands.Arg{{Name: "command", Description: "command to describe"}},
+		Handler: func(c tele.Context) error {
+			if name := strings.TrimSpace(c.Message().Payload); name != "" {
+				return r.sendCommandHelp(c, name, opts)
+			}
+			text, markup := r.renderHelpPage(c, 0, opts)
+			return tghelpers.SendText(c, text, &tele.SendOptions{ParseMode: tele.ModeMarkdownV2, ReplyMarkup: markup})
+		},
+	})
+	_ = r.RegisterCallback(helpCallbackKey, func(c tele.Context) error {
+		page, err := callbacks.PayloadInt(c)
+		if err != nil {
+			return nil
+		}
+		text, markup := r.renderHelpPage(c, page, opts)
+		return c.Edit(text, &tele.SendOptions{ParseMode: tele.ModeMarkdownV2, ReplyMarkup: markup})
+	})
+}
+
+func (r *Registry) sendCommandHelp(c tele.Context, name string, opts HelpOptions) error {
+	parts := strings.Fields(name)
+	key, cmd, ok := r.LookupCommand(parts[0])
+	if !ok || !r.commandVisible(c, cmd, opts) {
+		return tghelpers.SendText(c, i18n.T(c, i18n.KeyHelpUnknown, i18n.Args{"name": name}))
+	}
+	cmd.Description = r.localizedDescription(c, key, cmd)
+	for _, part := range parts[1:] {
+		subName, sub, found := lookupSubcommand(cmd.Subcommands, part)
+		if !found || !r.commandVisible(c, sub, opts) {
+			return tghelpers.SendText(c, i18n.T(c, i18n.KeyHelpUnknown, i18n.Args{"name": name}))
+		}
+		key += " " + subName
+		if sub.DescriptionKey != "" {
+			if text := i18n.T(c, sub.DescriptionKey); text != sub.DescriptionKey {
+				sub.Description = text
+			}
+		}
+		cmd = sub
+	}
+	text := commands.Help(key, cmd)
+	if len(cmd.Subcommands) > 0 {
+		text += "\n\n" + r.subcommandsHelp(c, key, cmd)
+	}
+	if len(cmd.Aliases) > 0 {
+		aliases := make([]string, len(cmd.Aliases))
+		for i, a := range cmd.Aliases {
+			aliases[i] = "/" + strings.TrimPrefix(a, "/")
+		}
+		text += "\n" + i18n.T(c, i18n.KeyHelpAliases, i18n.Args{"aliases": strings.Join(aliases, ", ")})
+	}
+	return tghelpers.SendText(c, text)
+}
+
+// renderHelpPage builds the MarkdownV2 text and navigation keyboard for page.
+func (r *Registry) renderHelpPage(c tele.Context, page int, opts HelpOptions) (string, *tele.ReplyMarkup) {
+	entries := r.visibleEntries(c, opts)
+	pages := (len(entries) + opts.PageSize - 1) / opts.PageSize
+	if pages == 0 {
+		pages = 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	if page >= pages {
+		page = pages - 1
+	}
+	start := page * opts.PageSize
+	end := start + opts.PageSize
+	if end > len(entries) {
+		end = len(entries)
+	}
+
+	var b strings.Builder
+	b.WriteString("*" + escapeMDV2(i18n.T(c, i18n.KeyHelpTitle)) + "*\n")
+	group := "\x00"
+	for _, e := range entries[start:end] {
+		if e.cmd.Category != group {
+			group = e.cmd.Category
+			title := group
+			if title == "" {
+				title = i18n.T(c, i18n.KeyHelpGeneral)
+			}
+			b.WriteString("\n_" + escapeMDV2(title) + "_\n")
+		}
+		b.WriteString(escapeMDV2(e.name) + " — " + escapeMDV2(r.localizedDescription(c, e.name, e.cmd)) + "\n")
+	}
+	b.WriteString("\n" + escapeMDV2(i18n.T(c, i18n.KeyHelpFooter, i18n.Args{"command": opts.Command})))
+
+	if pages == 1 {
+		return b.String(), nil
+	}
+	markup := &tele.ReplyMarkup{}
+	var row []tele.Btn
+	if page > 0 {
+		row = append(row, markup.Data("◀", helpCallbackKey, strconv.Itoa(page-1)))
+	}
+	row = append(row, markup.Data(strconv.Itoa(page+1)+"/"+strconv.Itoa(pages), helpCallbackKey, "-"))
+	if page < pages-1 {
+		row = append(row, markup.Data("▶", helpCallbackKey, strconv.Itoa(page+1)))
+	}
+	markup.Inline(markup.Row(row...))
+	return b.String(), markup
+}
+
+// visibleEntries lists commands the caller may run, ordered by category and name.
+func (r *Registry) visibleEntries(c tele.Context, opts HelpOptions) []helpEntry {
+	var out []helpEntry
+	for name, cmd := range r.commands {
+		if r.commandVisible(c, cmd, opts) {
+			out = append(out, helpEntry{name: name, cmd: cmd})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].cmd.Category != out[j].cmd.Category {
+			return out[i].cmd.Category < out[j].cmd.Category
+		}
+		return out[i].name < out[j].name
+	})
+	return out
+}
+
+// commandVisible mirrors the router guard; the authorizer and admins are
+// resolved per call, so they may be configured after EnableHelp.
+func (r *Registry) commandVisible(c tele.Context, cmd commands.Command, opts HelpOptions) bool {
+	if cmd.Hidden {
+		return false
+	}
+	if !cmd.AdminOnly && len(cmd.Permissions) == 0 {
+		return true
+	}
+	if opts.Authorizer == nil {
+		opts.Authorizer = r.Authorizer()
+	}
+	if opts.Authorizer == nil {
+		if !cmd.AdminOnly || len(cmd.Permissions) > 0 || c.Sender() == nil {
+			return false
+		}
+		admins := opts.AdminIDs
+		if len(admins) == 0 {
+			admins = append([]int64{r.adminOpts.AdminID}, r.adminOpts.AdminIDs...)
+		}
+		for _, id := range admins {
+			if id != 0 && id == c.Sender().ID {
+				return true
+			}
+		}
+		return false
+	}
+	perms := cmd.Permissions
+	if cmd.AdminOnly {
+		perms = append([]roles.Permission{roles.PermissionAll}, perms...)
+	}
+	ok, _ := opts.Authorizer.Allowed(c, perms...)
+	return ok
+}
+
+func (r *Registry) localizedDescription(c tele.Context, name string, cmd commands.Command) string {
+	key := commandDescriptionKey(name, cmd)
+	if text := i18n.T(c, key); text != key {
+		return text
+	}
+	return cmd.Description
+}
+
+func escapeMDV2(s string) string {
+	out, _ := format.EscapeMarkdown(s, format.MarkdownV2, "")
+	return out
+}
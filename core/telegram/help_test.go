@@ -0,0 +1,31 @@
+package telegram
+
+import (
+	"testing"
+
+	"github.com/m3rciful/gobot/core/telegram/commands"
+	"github.com/m3rciful/gobot/core/telegram/middleware"
+)
+
+func TestHelpShowsAdminCommandsToRegistryAdmins(t *testing.T) {
+	r := NewRegistry()
+	ban := commands.Command{Description: "Ban a user", AdminOnly: true}
+	r.SetAdminOptions(middleware.AdminOptions{AdminID: 1, AdminIDs: []int64{2}})
+
+	cases := []struct {
+		user int64
+		want bool
+	}{
+		{1, true},
+		{2, true},
+		{3, false},
+	}
+	for _, tc := range cases {
+		if got := r.commandVisible(commandContext(t, tc.user, "/help", ""), ban, HelpOptions{}); got != tc.want {
+			t.Errorf("user %d: visible = %v, want %v", tc.user, got, tc.want)
+		}
+	}
+	if r.commandVisible(commandContext(t, 2, "/help", ""), ban, HelpOptions{AdminIDs: []int64{1}}) {
+		t.Error("explicit AdminIDs must override the registry admins")
+	}
+}
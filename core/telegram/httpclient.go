@@ -1,11 +1,14 @@
 package telegram
 
 import (
+	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/m3rciful/gobot/core/telegram/netutil"
+	"github.com/m3rciful/gobot/core/tracing"
 )
 
 const (
@@ -51,7 +54,32 @@ type retryTransport struct {
 	backoff    time.Duration
 }
 
-func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+// RoundTrip traces every API call except getUpdates, whose long polls would
+// drown out the spans that matter. The span is a child of the span in the
+// request context; telebot requests carry none, so their spans start their
+// own trace and the update trace records the call through the send span of
+// the dispatcher job, tagged with the same telegram.method.
+func (t *retryTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	method := apiMethod(req)
+	if method != "getUpdates" {
+		var span *tracing.Span
+		_, span = tracing.Start(req.Context(), "telegram."+method, tracing.KindClient,
+			tracing.String("telegram.method", method))
+		defer func() {
+			if resp != nil {
+				span.SetAttrs(tracing.Int64("http.status_code", int64(resp.StatusCode)))
+				if resp.StatusCode >= http.StatusBadRequest && err == nil {
+					span.End(fmt.Errorf("telegram: %s", resp.Status))
+					return
+				}
+			}
+			span.End(err)
+		}()
+	}
+	return t.roundTrip(req)
+}
+
+func (t *retryTransport) roundTrip(req *http.Request) (*http.Response, error) {
 	base := t.base
 	if base == nil {
 		base = http.DefaultTransport
@@ -98,3 +126,19 @@ func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	return nil, lastErr
 }
+
+// apiMethod returns the Bot API method of req without the token-bearing path
+// prefix; file downloads are reported as "getFile.download".
+func apiMethod(req *http.Request) string {
+	path := req.URL.Path
+	if strings.HasPrefix(path, "/file/") {
+		return "getFile.download"
+	}
+	if i := strings.LastIndexByte(path, '/'); i >= 0 {
+		path = path[i+1:]
+	}
+	if path == "" {
+		return "unknown"
+	}
+	return path
+}
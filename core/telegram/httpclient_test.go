@@ -0,0 +1,72 @@
+package telegram
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/m3rciful/gobot/core/tracing"
+)
+
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []tracing.SpanData
+}
+
+func (r *spanRecorder) ExportSpans(_ context.Context, spans []tracing.SpanData) error {
+	r.mu.Lock()
+	r.spans = append(r.spans, spans...)
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *spanRecorder) Shutdown(context.Context) error { return nil }
+
+func TestAPISpansFollowRequestContext(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer api.Close()
+	rec := &spanRecorder{}
+	shutdown := tracing.Setup(tracing.Options{Exporter: rec})
+	client := &http.Client{Transport: &retryTransport{}}
+
+	ctx, parent := tracing.Start(context.Background(), "send.text", tracing.KindInternal)
+	for _, reqCtx := range []context.Context{ctx, context.Background()} {
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, api.URL+"/bottest/sendMessage", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	parent.End(nil)
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	var send, child, standalone *tracing.SpanData
+	for i := range rec.spans {
+		s := &rec.spans[i]
+		switch {
+		case s.Name == "send.text":
+			send = s
+		case s.Name != "telegram.sendMessage":
+		case s.ParentID != "":
+			child = s
+		default:
+			standalone = s
+		}
+	}
+	if send == nil || child == nil || child.ParentID != send.SpanID || child.TraceID != send.TraceID {
+		t.Fatalf("API span with span in context = %+v, want child of %+v", child, send)
+	}
+	if standalone == nil || standalone.TraceID == send.TraceID {
+		t.Fatalf("API span without span in context = %+v, want its own trace", standalone)
+	}
+}
@@ -0,0 +1,379 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLocale is used when a Bundle is created without an explicit default.
+const DefaultLocale = "en"
+
+// Message is a single catalog entry. Plain strings populate Other only;
+// plural entries fill the categories used by the locale.
+type Message struct {
+	Zero  string
+	One   string
+	Two   string
+	Few   string
+	Many  string
+	Other string
+}
+
+// Args carries named values substituted into {name} placeholders.
+// The "count" value also selects the plural form.
+type Args map[string]any
+
+// CountArg is the argument name used for plural selection.
+const CountArg = "count"
+
+// Bundle holds message catalogs for all supported locales.
+type Bundle struct {
+	mu            sync.RWMutex
+	defaultLocale string
+	catalogs      map[string]map[string]Message
+}
+
+// NewBundle creates an empty bundle with the provided default locale.
+func NewBundle(defaultLocale string) *Bundle {
+	defaultLocale = NormalizeLocale(defaultLocale)
+	if defaultLocale == "" {
+		defaultLocale = DefaultLocale
+	}
+	return &Bundle{
+		defaultLocale: defaultLocale,
+		catalogs:      make(map[string]map[string]Message),
+	}
+}
+
+// DefaultLocale returns the locale used when no better match exists.
+func (b *Bundle) DefaultLocale() string {
+	if b == nil {
+		return DefaultLocale
+	}
+	return b.defaultLocale
+}
+
+// AddMessages merges messages into the catalog of locale.
+func (b *Bundle) AddMessages(locale string, messages map[string]Message) {
+	locale = NormalizeLocale(locale)
+	if b == nil || locale == "" {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	catalog, ok := b.catalogs[locale]
+	if !ok {
+		catalog = make(map[string]Message, len(messages))
+		b.catalogs[locale] = catalog
+	}
+	for k, m := range messages {
+		catalog[k] = m
+	}
+}
+
+// AddStrings merges plain (non-plural) strings into the catalog of locale.
+func (b *Bundle) AddStrings(locale string, messages map[string]string) {
+	converted := make(map[string]Message, len(messages))
+	for k, v := range messages {
+		converted[k] = Message{Other: v}
+	}
+	b.AddMessages(locale, converted)
+}
+
+// LoadDir loads every *.yaml, *.yml and *.json catalog in dir.
+// The file name without extension is the locale ("en.yaml", "pt-BR.json").
+func (b *Bundle) LoadDir(dir string) error {
+	return b.LoadFS(os.DirFS(dir), ".")
+}
+
+// LoadFS loads catalogs from dir inside fsys, e.g. an embed.FS.
+func (b *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("i18n: read dir %s: %w", dir, err)
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		ext := path.Ext(e.Name())
+		switch ext {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return fmt.Errorf("i18n: read %s: %w", e.Name(), err)
+		}
+		locale := strings.TrimSuffix(e.Name(), ext)
+		if err := b.Load(locale, ext, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load parses a single catalog encoded as YAML or JSON (selected by ext).
+// Nested maps are flattened into dotted keys; a map containing only plural
+// categories (one, few, many, other, ...) is treated as a plural message.
+func (b *Bundle) Load(locale, ext string, data []byte) error {
+	var raw map[string]any
+	switch ext {
+	case ".json":
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("i18n: parse %s catalog: %w", locale, err)
+		}
+	default:
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("i18n: parse %s catalog: %w", locale, err)
+		}
+	}
+	messages := make(map[string]Message)
+	if err := flatten("", raw, messages); err != nil {
+		return fmt.Errorf("i18n: %s catalog: %w", locale, err)
+	}
+	b.AddMessages(locale, messages)
+	return nil
+}
+
+func flatten(prefix string, raw map[string]any, out map[string]Message) error {
+	for k, v := range raw {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch val := v.(type) {
+		case string:
+			out[key] = Message{Other: val}
+		case map[string]any:
+			if msg, ok := pluralMessage(val); ok {
+				out[key] = msg
+				continue
+			}
+			if err := flatten(key, val, out); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported value for key %q", key)
+		}
+	}
+	return nil
+}
+
+func pluralMessage(raw map[string]any) (Message, bool) {
+	if _, ok := raw[PluralOther]; !ok {
+		return Message{}, false
+	}
+	var msg Message
+	for k, v := range raw {
+		if _, ok := pluralCategories[k]; !ok {
+			return Message{}, false
+		}
+		s, ok := v.(string)
+		if !ok {
+			return Message{}, false
+		}
+		switch k {
+		case PluralZero:
+			msg.Zero = s
+		case PluralOne:
+			msg.One = s
+		case PluralTwo:
+			msg.Two = s
+		case PluralFew:
+			msg.Few = s
+		case PluralMany:
+			msg.Many = s
+		case PluralOther:
+			msg.Other = s
+		}
+	}
+	return msg, true
+}
+
+// Locales returns the sorted list of locales with a loaded catalog.
+func (b *Bundle) Locales() []string {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make([]string, 0, len(b.catalogs))
+	for l := range b.catalogs {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Match returns the best supported locale for the requested one:
+// exact match, then base language, otherwise an empty string.
+func (b *Bundle) Match(locale string) string {
+	locale = NormalizeLocale(locale)
+	if b == nil || locale == "" {
+		return ""
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if _, ok := b.catalogs[locale]; ok {
+		return locale
+	}
+	if base := baseLanguage(locale); base != locale {
+		if _, ok := b.catalogs[base]; ok {
+			return base
+		}
+	}
+	return ""
+}
+
+// Lookup returns the message stored for key in exactly the given locale.
+func (b *Bundle) Lookup(locale, key string) (Message, bool) {
+	if b == nil {
+		return Message{}, false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	msg, ok := b.catalogs[NormalizeLocale(locale)][key]
+	return msg, ok
+}
+
+// Translate renders key for locale, falling back to the default locale,
+// the built-in core messages and finally the key itself.
+func (b *Bundle) Translate(locale, key string, args ...Args) string {
+	var merged Args
+	switch len(args) {
+	case 0:
+	case 1:
+		merged = args[0]
+	default:
+		merged = make(Args)
+		for _, a := range args {
+			for k, v := range a {
+				merged[k] = v
+			}
+		}
+	}
+
+	if b != nil {
+		if matched := b.Match(locale); matched != "" {
+			if msg, ok := b.Lookup(matched, key); ok {
+				return render(matched, msg, merged)
+			}
+		}
+		if msg, ok := b.Lookup(b.defaultLocale, key); ok {
+			return render(b.defaultLocale, msg, merged)
+		}
+	}
+	if text, ok := builtin[key]; ok {
+		return render(DefaultLocale, Message{Other: text}, merged)
+	}
+	return key
+}
+
+// Has reports whether locale (or its base language) defines key.
+func (b *Bundle) Has(locale, key string) bool {
+	matched := b.Match(locale)
+	if matched == "" {
+		return false
+	}
+	_, ok := b.Lookup(matched, key)
+	return ok
+}
+
+func render(locale string, msg Message, args Args) string {
+	text := msg.Other
+	if n, ok := countFrom(args); ok {
+		if form := msg.form(PluralCategory(locale, n)); form != "" {
+			text = form
+		}
+	}
+	if len(args) == 0 || !strings.ContainsRune(text, '{') {
+		return text
+	}
+	var sb strings.Builder
+	sb.Grow(len(text))
+	for {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(text[open:], '}')
+		if end < 0 {
+			break
+		}
+		name := text[open+1 : open+end]
+		if v, ok := args[name]; ok {
+			sb.WriteString(text[:open])
+			sb.WriteString(fmt.Sprint(v))
+		} else {
+			sb.WriteString(text[:open+end+1])
+		}
+		text = text[open+end+1:]
+	}
+	sb.WriteString(text)
+	return sb.String()
+}
+
+func (m Message) form(category string) string {
+	switch category {
+	case PluralZero:
+		return m.Zero
+	case PluralOne:
+		return m.One
+	case PluralTwo:
+		return m.Two
+	case PluralFew:
+		return m.Few
+	case PluralMany:
+		return m.Many
+	}
+	return m.Other
+}
+
+func countFrom(args Args) (int, bool) {
+	v, ok := args[CountArg]
+	if !ok {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int8:
+		return int(n), true
+	case int16:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case uint:
+		return int(n), true
+	case uint8:
+		return int(n), true
+	case uint16:
+		return int(n), true
+	case uint32:
+		return int(n), true
+	case uint64:
+		return int(n), true
+	case string:
+		i, err := strconv.Atoi(n)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+// NormalizeLocale lowercases a locale tag and converts "_" to "-".
+func NormalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
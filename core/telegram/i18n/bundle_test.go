@@ -0,0 +1,74 @@
+package i18n
+
+import "testing"
+
+const ruCatalog = `
+greeting: "Привет, {name}!"
+cart:
+  items:
+    one: "{count} товар"
+    few: "{count} товара"
+    many: "{count} товаров"
+    other: "{count} товара"
+`
+
+func TestBundleTranslate(t *testing.T) {
+	b := NewBundle("en")
+	b.AddMessages("en", map[string]Message{
+		"greeting":   {Other: "Hello, {name}!"},
+		"cart.items": {One: "{count} item", Other: "{count} items"},
+	})
+	if err := b.Load("ru", ".yaml", []byte(ruCatalog)); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	tests := []struct {
+		locale string
+		key    string
+		args   Args
+		want   string
+	}{
+		{"en", "greeting", Args{"name": "Ann"}, "Hello, Ann!"},
+		{"ru-RU", "greeting", Args{"name": "Аня"}, "Привет, Аня!"},
+		{"de", "greeting", Args{"name": "Ann"}, "Hello, Ann!"},
+		{"en", "cart.items", Args{"count": 1}, "1 item"},
+		{"en", "cart.items", Args{"count": 5}, "5 items"},
+		{"ru", "cart.items", Args{"count": 1}, "1 товар"},
+		{"ru", "cart.items", Args{"count": 3}, "3 товара"},
+		{"ru", "cart.items", Args{"count": 11}, "11 товаров"},
+		{"ru", "cart.items", Args{"count": 22}, "22 товара"},
+		{"ru", KeyUnsupportedAction, nil, "Unsupported action"},
+		{"en", "missing.key", nil, "missing.key"},
+		{"en", "greeting", Args{}, "Hello, {name}!"},
+	}
+	for _, tt := range tests {
+		if got := b.Translate(tt.locale, tt.key, tt.args); got != tt.want {
+			t.Errorf("Translate(%q, %q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		locale string
+		n      int
+		want   string
+	}{
+		{"en", 0, PluralOther},
+		{"en", 1, PluralOne},
+		{"fr", 0, PluralOne},
+		{"ru", 21, PluralOne},
+		{"ru", 14, PluralMany},
+		{"pl", 22, PluralFew},
+		{"pl", 21, PluralMany},
+		{"cs", 3, PluralFew},
+		{"ja", 1, PluralOther},
+		{"ar", 2, PluralTwo},
+		{"ar", 105, PluralFew},
+	}
+	for _, tt := range tests {
+		if got := PluralCategory(tt.locale, tt.n); got != tt.want {
+			t.Errorf("PluralCategory(%q, %d) = %q, want %q", tt.locale, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package i18n provides message catalogs, plural rules and per-user locale
+// resolution for user-facing Telegram bot strings.
+package i18n
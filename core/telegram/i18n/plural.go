@@ -0,0 +1,96 @@
+package i18n
+
+import "strings"
+
+// Plural categories as defined by CLDR.
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+var pluralCategories = map[string]struct{}{
+	PluralZero:  {},
+	PluralOne:   {},
+	PluralTwo:   {},
+	PluralFew:   {},
+	PluralMany:  {},
+	PluralOther: {},
+}
+
+// PluralCategory returns the CLDR cardinal plural category of n for locale.
+// Unknown languages use the English rule (one/other).
+func PluralCategory(locale string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	mod10, mod100 := n%10, n%100
+	switch baseLanguage(locale) {
+	case "ja", "zh", "ko", "vi", "th", "id", "ms", "lo", "my", "km":
+		return PluralOther
+	case "ru", "uk", "be":
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return PluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		default:
+			return PluralMany
+		}
+	case "pl":
+		switch {
+		case n == 1:
+			return PluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		default:
+			return PluralMany
+		}
+	case "cs", "sk":
+		switch {
+		case n == 1:
+			return PluralOne
+		case n >= 2 && n <= 4:
+			return PluralFew
+		default:
+			return PluralOther
+		}
+	case "fr", "pt":
+		if n == 0 || n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	case "ar":
+		switch {
+		case n == 0:
+			return PluralZero
+		case n == 1:
+			return PluralOne
+		case n == 2:
+			return PluralTwo
+		case mod100 >= 3 && mod100 <= 10:
+			return PluralFew
+		case mod100 >= 11:
+			return PluralMany
+		default:
+			return PluralOther
+		}
+	default:
+		if n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	}
+}
+
+// baseLanguage returns the language subtag of a locale ("pt-BR" -> "pt").
+func baseLanguage(locale string) string {
+	locale = NormalizeLocale(locale)
+	if i := strings.IndexByte(locale, '-'); i > 0 {
+		return locale[:i]
+	}
+	return locale
+}
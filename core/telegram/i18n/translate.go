@@ -0,0 +1,164 @@
+package i18n
+
+import (
+	"context"
+	"sync/atomic"
+
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Keys of user-facing strings emitted by the core itself.
+// Bots may override them in their catalogs.
+const (
+	KeyUnsupportedAction = "core.unsupported_action"
+	KeyCancel            = "core.cancel"
+	KeyBanUsage          = "core.access.ban_usage"
+	KeyUnbanUsage        = "core.access.unban_usage"
+	KeyBanAdmin          = "core.access.ban_admin"
+	KeyUserBanned        = "core.access.banned"
+	KeyUserUnbanned      = "core.access.unbanned"
+	KeyInviteCreated     = "core.access.invite_created"
+	KeyArgsInvalid       = "core.args.invalid"
+	KeyHelpDescription   = "core.help.description"
+	KeyHelpTitle         = "core.help.title"
+	KeyHelpGeneral       = "core.help.general"
+	KeyHelpFooter        = "core.help.footer"
+	KeyHelpUnknown       = "core.help.unknown"
+	KeyHelpAliases       = "core.help.aliases"
+	KeyPageTitle         = "core.page.title"
+	KeyPageEmpty         = "core.page.empty"
+	// KeyCalendarMonths and KeyCalendarWeekdays hold space-separated names,
+	// January first and Monday first respectively.
+	KeyCalendarMonths   = "core.calendar.months"
+	KeyCalendarWeekdays = "core.calendar.weekdays"
+	KeyMenuBack         = "core.menu.back"
+	KeyMenuHome         = "core.menu.home"
+	KeyConfirmYes       = "core.confirm.yes"
+	KeyConfirmNo        = "core.confirm.no"
+	KeyConfirmAccepted  = "core.confirm.accepted"
+	KeyConfirmDeclined  = "core.confirm.declined"
+	KeyConfirmExpired   = "core.confirm.expired"
+)
+
+// builtin holds English defaults for core keys so the core works without catalogs.
+var builtin = map[string]string{
+	KeyUnsupportedAction: "Unsupported action",
+	KeyCancel:            "❌ Cancel",
+	KeyBanUsage:          "Usage: /ban <user_id> [reason] or reply to a message with /ban",
+	KeyUnbanUsage:        "Usage: /unban <user_id>",
+	KeyBanAdmin:          "Admins cannot be banned",
+	KeyUserBanned:        "User {id} banned",
+	KeyUserUnbanned:      "User {id} unbanned",
+	KeyInviteCreated:     "Invite: {link}",
+	KeyArgsInvalid:       "⚠️ {error}\nUsage: {usage}",
+	KeyHelpDescription:   "Show available commands",
+	KeyHelpTitle:         "Available commands",
+	KeyHelpGeneral:       "General",
+	KeyHelpFooter:        "Send {command} <command> for details.",
+	KeyHelpUnknown:       "Unknown command: {name}",
+	KeyHelpAliases:       "Aliases: {aliases}",
+	KeyPageTitle:         "Page {page} of {pages}",
+	KeyPageEmpty:         "Nothing to show.",
+	KeyCalendarMonths:    "January February March April May June July August September October November December",
+	KeyCalendarWeekdays:  "Mo Tu We Th Fr Sa Su",
+	KeyMenuBack:          "◀ Back",
+	KeyMenuHome:          "🏠 Home",
+	KeyConfirmYes:        "✅ Yes",
+	KeyConfirmNo:         "❌ No",
+	KeyConfirmAccepted:   "✅ Confirmed",
+	KeyConfirmDeclined:   "❌ Cancelled",
+	KeyConfirmExpired:    "⌛ This request has expired.",
+}
+
+// Builtin returns the English default of a core key, or "" for other keys.
+func Builtin(key string) string {
+	return builtin[key]
+}
+
+const localeKey = "i18n_locale"
+
+var globalBundle atomic.Pointer[Bundle]
+
+// SetBundle wires the bundle used by T and the locale middleware.
+func SetBundle(b *Bundle) {
+	globalBundle.Store(b)
+}
+
+// CurrentBundle returns the bundle configured via SetBundle, if any.
+func CurrentBundle() *Bundle {
+	return globalBundle.Load()
+}
+
+// PreferenceStore returns a locale explicitly chosen by the user, if any.
+type PreferenceStore interface {
+	UserLocale(ctx context.Context, userID int64) (string, bool)
+}
+
+// Options configures the locale middleware.
+type Options struct {
+	// Bundle defaults to CurrentBundle when nil.
+	Bundle      *Bundle
+	Preferences PreferenceStore
+}
+
+// Middleware resolves the locale for each update and stores it on the context.
+// Resolution order: user preference, Telegram language_code, bundle default.
+func Middleware(opts Options) tele.MiddlewareFunc {
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			bundle := opts.Bundle
+			if bundle == nil {
+				bundle = CurrentBundle()
+			}
+			c.Set(localeKey, resolve(c, bundle, opts.Preferences))
+			return next(c)
+		}
+	}
+}
+
+// SetLocale overrides the locale for the rest of the update, e.g. right after
+// the user changed their language preference.
+func SetLocale(c tele.Context, locale string) {
+	if c == nil {
+		return
+	}
+	c.Set(localeKey, NormalizeLocale(locale))
+}
+
+// Locale returns the locale resolved for the update. Without the middleware
+// it falls back to the sender's language_code matched against the bundle.
+func Locale(c tele.Context) string {
+	if c != nil {
+		if l, ok := c.Get(localeKey).(string); ok && l != "" {
+			return l
+		}
+	}
+	return resolve(c, CurrentBundle(), nil)
+}
+
+// T translates key for the locale of the current update.
+func T(c tele.Context, key string, args ...Args) string {
+	return CurrentBundle().Translate(Locale(c), key, args...)
+}
+
+func resolve(c tele.Context, bundle *Bundle, prefs PreferenceStore) string {
+	var user *tele.User
+	if c != nil {
+		user = c.Sender()
+	}
+	if user != nil && prefs != nil {
+		if l, ok := prefs.UserLocale(tghelpers.BuildContext(c), user.ID); ok {
+			if matched := bundle.Match(l); matched != "" {
+				return matched
+			}
+		}
+	}
+	if user != nil && user.LanguageCode != "" {
+		if matched := bundle.Match(user.LanguageCode); matched != "" {
+			return matched
+		}
+	}
+	return bundle.DefaultLocale()
+}
@@ -0,0 +1,96 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/m3rciful/gobot/core/logger"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// InlineRequest is an inline query as seen by an InlineHandler.
+type InlineRequest struct {
+	// Prefix is the route prefix that matched.
+	Prefix string
+	// Text is the query with the prefix removed and surrounding spaces trimmed.
+	Text string
+	// Offset is the index of the first requested result (0 for the first page).
+	Offset int
+	// Limit is the page size configured for the route.
+	Limit int
+}
+
+// InlineAnswer is one page of inline results.
+type InlineAnswer struct {
+	Results tele.Results
+	// More requests a next_offset so the client can load the following page.
+	More bool
+	// CacheTime overrides the route cache time (seconds) when positive.
+	CacheTime int
+}
+
+// InlineHandler produces the results for req.
+type InlineHandler func(c tele.Context, req InlineRequest) (InlineAnswer, error)
+
+// InlineRoute serves inline queries starting with Prefix; an empty prefix
+// serves all queries no other route matches. The longest matching prefix wins.
+type InlineRoute struct {
+	Prefix  string
+	Handler InlineHandler
+	// OnChosen handles chosen_inline_result updates whose query matches Prefix
+	// (requires inline feedback to be enabled with @BotFather).
+	OnChosen tele.HandlerFunc
+	// CacheTime is the cache_time hint in seconds; 0 uses Telegram's default (300).
+	CacheTime int
+	// Personal marks results as valid only for the querying user.
+	Personal bool
+	// PageSize bounds results per answer (default 20, Telegram allows up to 50).
+	PageSize int
+}
+
+// RegisterInline adds an inline query route.
+func (r *Registry) RegisterInline(route InlineRoute) error {
+	if r == nil || route.Handler == nil {
+		logger.TWire.LogAttrs(context.Background(), slog.LevelWarn, "register.inline.skip",
+			slog.String("prefix", route.Prefix),
+			slog.Bool("handler_nil", route.Handler == nil),
+		)
+		return errors.New("invalid inline registration")
+	}
+	r.inlineMu.Lock()
+	defer r.inlineMu.Unlock()
+	if r.inline == nil {
+		r.inline = make(map[string]InlineRoute)
+	}
+	if _, exists := r.inline[route.Prefix]; exists {
+		logger.TWire.LogAttrs(context.Background(), slog.LevelWarn, "register.inline.duplicate",
+			slog.String("prefix", route.Prefix),
+		)
+		return fmt.Errorf("inline route already registered: %q", route.Prefix)
+	}
+	r.inline[route.Prefix] = route
+	return nil
+}
+
+// MatchInline returns the route with the longest prefix of query.
+func (r *Registry) MatchInline(query string) (InlineRoute, bool) {
+	if r == nil {
+		return InlineRoute{}, false
+	}
+	r.inlineMu.RLock()
+	defer r.inlineMu.RUnlock()
+	var (
+		best  InlineRoute
+		found bool
+	)
+	for prefix, route := range r.inline {
+		if strings.HasPrefix(query, prefix) && (!found || len(prefix) > len(best.Prefix)) {
+			best, found = route, true
+		}
+	}
+	return best, found
+}
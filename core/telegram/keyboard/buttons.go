@@ -0,0 +1,305 @@
+package keyboard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Telegram keyboard limits checked by BuildInline, BuildReply and Validate.
+const (
+	MaxInlineButtons      = 100
+	MaxInlineButtonsInRow = 8
+	MaxReplyButtons       = 300
+	MaxReplyButtonsInRow  = 12
+	MaxCopyTextLen        = 256
+)
+
+// ErrInvalidKeyboard reports a keyboard Telegram would reject.
+var ErrInvalidKeyboard = errors.New("keyboard: invalid keyboard")
+
+// DataBtn returns a callback button handled by the callback registered under unique.
+func DataBtn(text, unique, data string) InlineBtn {
+	return InlineBtn{Text: text, Unique: unique, Data: data}
+}
+
+// URLBtn returns a button opening url; tg:// links are allowed.
+func URLBtn(text, url string) InlineBtn {
+	return InlineBtn{Text: text, URL: url}
+}
+
+// WebAppBtn returns a button launching the Web App at url (HTTPS only).
+func WebAppBtn(text, url string) InlineBtn {
+	return InlineBtn{Text: text, WebApp: &tele.WebApp{URL: url}}
+}
+
+// LoginBtn returns a button authorizing the user on the site at login.URL.
+func LoginBtn(text string, login *tele.Login) InlineBtn {
+	return InlineBtn{Text: text, Login: login}
+}
+
+// SwitchInlineBtn returns a button that lets the user pick a chat and starts
+// an inline query to the bot there, prefilled with query. An empty query
+// inserts only the bot's username.
+func SwitchInlineBtn(text, query string) InlineBtn {
+	return InlineBtn{Text: text, SwitchInline: &query}
+}
+
+// SwitchInlineHereBtn is like SwitchInlineBtn but stays in the current chat.
+func SwitchInlineHereBtn(text, query string) InlineBtn {
+	return InlineBtn{Text: text, SwitchInlineHere: &query}
+}
+
+// SwitchInlineChosenBtn is like SwitchInlineBtn but limits the chat types the user can pick.
+func SwitchInlineChosenBtn(text string, chosen *tele.SwitchInlineQuery) InlineBtn {
+	return InlineBtn{Text: text, SwitchInlineChosen: chosen}
+}
+
+// CopyTextBtn returns a button copying value to the clipboard.
+func CopyTextBtn(text, value string) InlineBtn {
+	return InlineBtn{Text: text, CopyText: value}
+}
+
+// PayBtn returns the pay button of an invoice; it must come first in the first row.
+func PayBtn(text string) InlineBtn {
+	return InlineBtn{Text: text, Pay: true}
+}
+
+// Inline converts b to a telebot inline button.
+func (b InlineBtn) Inline() tele.InlineButton {
+	btn := tele.InlineButton{
+		Unique:                b.Unique,
+		Text:                  b.Text,
+		URL:                   b.URL,
+		Data:                  b.Data,
+		InlineQueryChosenChat: b.SwitchInlineChosen,
+		Login:                 b.Login,
+		WebApp:                b.WebApp,
+		Pay:                   b.Pay,
+	}
+	if b.SwitchInline != nil {
+		btn.InlineQuery = *b.SwitchInline
+		if btn.InlineQuery == "" && btn.InlineQueryChosenChat == nil {
+			// An empty switch_inline_query would be dropped from the JSON;
+			// the chosen-chat form with every chat type allowed is equivalent.
+			btn.InlineQueryChosenChat = &tele.SwitchInlineQuery{
+				AllowUserChats: true, AllowBotChats: true, AllowGroupChats: true, AllowChannelChats: true,
+			}
+		}
+	}
+	if b.SwitchInlineHere != nil {
+		btn.InlineQueryChat = *b.SwitchInlineHere
+	}
+	if b.CopyText != "" {
+		btn.CopyText = &tele.CopyTextButton{Text: b.CopyText}
+	}
+	return btn
+}
+
+// BuildInline is InlineButtonsRows with Telegram's constraints checked.
+func BuildInline(rows ...[]InlineBtn) (*tele.ReplyMarkup, error) {
+	markup := InlineButtonsRows(rows...)
+	here := func(i, j int) bool { return rows[i][j].SwitchInlineHere != nil }
+	if err := validateInline(markup.InlineKeyboard, here); err != nil {
+		return nil, err
+	}
+	return markup, nil
+}
+
+// ContactBtn returns a reply button sharing the user's phone number (private chats only).
+func ContactBtn(text string) tele.ReplyButton {
+	return tele.ReplyButton{Text: text, Contact: true}
+}
+
+// LocationBtn returns a reply button sharing the user's location (private chats only).
+func LocationBtn(text string) tele.ReplyButton {
+	return tele.ReplyButton{Text: text, Location: true}
+}
+
+// PollBtn returns a reply button asking the user to create a poll; an empty
+// kind allows both regular polls and quizzes.
+func PollBtn(text string, kind tele.PollType) tele.ReplyButton {
+	if kind == "" {
+		kind = tele.PollAny
+	}
+	return tele.ReplyButton{Text: text, Poll: kind}
+}
+
+// UsersBtn returns a reply button asking the user to share users; the result
+// arrives as a users_shared service message carrying req.ID.
+func UsersBtn(text string, req *tele.ReplyRecipient) tele.ReplyButton {
+	return tele.ReplyButton{Text: text, User: req}
+}
+
+// ChatBtn returns a reply button asking the user to share a chat; the result
+// arrives as a chat_shared service message carrying req.ID.
+func ChatBtn(text string, req *tele.ReplyRecipient) tele.ReplyButton {
+	return tele.ReplyButton{Text: text, Chat: req}
+}
+
+// ReplyWebAppBtn returns a reply button launching the Web App at url.
+func ReplyWebAppBtn(text, url string) tele.ReplyButton {
+	return tele.ReplyButton{Text: text, WebApp: &tele.WebApp{URL: url}}
+}
+
+// BuildReply builds a resized reply keyboard from rows of buttons and checks
+// Telegram's constraints.
+func BuildReply(rows ...[]tele.ReplyButton) (*tele.ReplyMarkup, error) {
+	markup := &tele.ReplyMarkup{ResizeKeyboard: true, ReplyKeyboard: rows}
+	if err := Validate(markup); err != nil {
+		return nil, err
+	}
+	return markup, nil
+}
+
+// Validate checks the inline and reply keyboards of markup against Telegram's
+// limits: button counts, callback data length and exactly one action per
+// inline button. A raw inline button with an empty
+// switch_inline_query_current_chat counts as having no action; build it
+// with SwitchInlineHereBtn and BuildInline instead.
+func Validate(markup *tele.ReplyMarkup) error {
+	if markup == nil {
+		return nil
+	}
+	if err := validateInline(markup.InlineKeyboard, nil); err != nil {
+		return err
+	}
+	return validateReply(markup.ReplyKeyboard)
+}
+
+// validateInline checks inline rows; here, when set, reports buttons built
+// as switch-inline-here, whose query may legitimately be empty.
+func validateInline(rows [][]tele.InlineButton, here func(i, j int) bool) error {
+	total := 0
+	for i, row := range rows {
+		if len(row) == 0 {
+			return fmt.Errorf("%w: inline row %d is empty", ErrInvalidKeyboard, i)
+		}
+		if len(row) > MaxInlineButtonsInRow {
+			return fmt.Errorf("%w: inline row %d has %d buttons, max %d", ErrInvalidKeyboard, i, len(row), MaxInlineButtonsInRow)
+		}
+		total += len(row)
+		for j, b := range row {
+			if err := validateInlineButton(b, i == 0 && j == 0, here != nil && here(i, j)); err != nil {
+				return fmt.Errorf("%w: row %d button %d: %v", ErrInvalidKeyboard, i, j, err)
+			}
+		}
+	}
+	if total > MaxInlineButtons {
+		return fmt.Errorf("%w: %d inline buttons, max %d", ErrInvalidKeyboard, total, MaxInlineButtons)
+	}
+	return nil
+}
+
+func validateInlineButton(b tele.InlineButton, first, here bool) error {
+	if strings.TrimSpace(b.Text) == "" {
+		return errors.New("empty text")
+	}
+	actions := 0
+	count := func(set bool) {
+		if set {
+			actions++
+		}
+	}
+	data := callbackData(b)
+	count(data != "")
+	count(b.URL != "")
+	count(b.InlineQuery != "")
+	count(b.InlineQueryChat != "" || here)
+	count(b.InlineQueryChosenChat != nil)
+	count(b.Login != nil)
+	count(b.WebApp != nil)
+	count(b.CallbackGame != nil)
+	count(b.Pay)
+	count(b.CopyText != nil)
+	switch {
+	case actions == 0:
+		return errors.New("no action")
+	case actions > 1:
+		return errors.New("more than one action")
+	case len(data) > callbacks.MaxDataLen:
+		return fmt.Errorf("callback data is %d bytes, max %d", len(data), callbacks.MaxDataLen)
+	case b.Pay && !first:
+		return errors.New("pay button must be the first button of the first row")
+	case b.WebApp != nil && !strings.HasPrefix(b.WebApp.URL, "https://"):
+		return errors.New("web app url must use https")
+	case b.Login != nil && b.Login.URL == "":
+		return errors.New("login url is empty")
+	case b.CopyText != nil && (b.CopyText.Text == "" || utf8.RuneCountInString(b.CopyText.Text) > MaxCopyTextLen):
+		return fmt.Errorf("copy text must be 1-%d characters", MaxCopyTextLen)
+	}
+	return nil
+}
+
+// callbackData returns the callback_data Telegram will receive for b,
+// including the "\f<unique>|" prefix telebot adds when sending.
+func callbackData(b tele.InlineButton) string {
+	if b.Unique == "" {
+		return b.Data
+	}
+	if b.Data == "" {
+		return "\f" + b.Unique
+	}
+	return "\f" + b.Unique + "|" + b.Data
+}
+
+func validateReply(rows [][]tele.ReplyButton) error {
+	total := 0
+	ids := make(map[int32]bool)
+	for i, row := range rows {
+		if len(row) == 0 {
+			return fmt.Errorf("%w: reply row %d is empty", ErrInvalidKeyboard, i)
+		}
+		if len(row) > MaxReplyButtonsInRow {
+			return fmt.Errorf("%w: reply row %d has %d buttons, max %d", ErrInvalidKeyboard, i, len(row), MaxReplyButtonsInRow)
+		}
+		total += len(row)
+		for j, b := range row {
+			if err := validateReplyButton(b, ids); err != nil {
+				return fmt.Errorf("%w: row %d button %d: %v", ErrInvalidKeyboard, i, j, err)
+			}
+		}
+	}
+	if total > MaxReplyButtons {
+		return fmt.Errorf("%w: %d reply buttons, max %d", ErrInvalidKeyboard, total, MaxReplyButtons)
+	}
+	return nil
+}
+
+func validateReplyButton(b tele.ReplyButton, ids map[int32]bool) error {
+	if strings.TrimSpace(b.Text) == "" {
+		return errors.New("empty text")
+	}
+	actions := 0
+	for _, set := range []bool{b.Contact, b.Location, b.Poll != "", b.User != nil, b.Chat != nil, b.WebApp != nil} {
+		if set {
+			actions++
+		}
+	}
+	if actions > 1 {
+		return errors.New("more than one request")
+	}
+	req := b.User
+	if req == nil {
+		req = b.Chat
+	}
+	if req != nil {
+		if ids[req.ID] {
+			return fmt.Errorf("duplicate request id %d", req.ID)
+		}
+		ids[req.ID] = true
+		// Zero omits max_quantity, which Telegram treats as 1.
+		if b.User != nil && (req.Quantity < 0 || req.Quantity > 10) {
+			return errors.New("users request quantity must be 1-10, or 0 for the default")
+		}
+	}
+	if b.WebApp != nil && !strings.HasPrefix(b.WebApp.URL, "https://") {
+		return errors.New("web app url must use https")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package keyboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestBuildInlineValidates(t *testing.T) {
+	if _, err := BuildInline(
+		[]InlineBtn{PayBtn("Pay"), URLBtn("Site", "https://example.com")},
+		[]InlineBtn{DataBtn("Ok", "ok", "1"), CopyTextBtn("Copy", "code"), SwitchInlineBtn("Share", "")},
+		[]InlineBtn{SwitchInlineHereBtn("Search", "")},
+	); err != nil {
+		t.Fatalf("valid keyboard rejected: %v", err)
+	}
+
+	tooMany := make([]InlineBtn, MaxInlineButtonsInRow+1)
+	for i := range tooMany {
+		tooMany[i] = DataBtn("x", "k", "")
+	}
+	cases := map[string][][]InlineBtn{
+		"row width":    {tooMany},
+		"data length":  {{DataBtn("x", "key", strings.Repeat("a", 64))}},
+		"pay position": {{URLBtn("Site", "https://example.com"), PayBtn("Pay")}},
+		"two actions":  {{{Text: "x", URL: "https://example.com", Pay: true}}},
+		"no action":    {{{Text: "x"}}},
+		"empty text":   {{URLBtn(" ", "https://example.com")}},
+		"web app http": {{WebAppBtn("App", "http://example.com")}},
+	}
+	for name, rows := range cases {
+		if _, err := BuildInline(rows...); !errors.Is(err, ErrInvalidKeyboard) {
+			t.Errorf("%s: got %v, want ErrInvalidKeyboard", name, err)
+		}
+	}
+	if err := Validate(&tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{{{Text: "x"}}}}); !errors.Is(err, ErrInvalidKeyboard) {
+		t.Errorf("raw button without action: got %v", err)
+	}
+}
+
+func TestBuildReplyValidates(t *testing.T) {
+	if _, err := BuildReply(
+		[]tele.ReplyButton{ContactBtn("Phone"), LocationBtn("Where")},
+		[]tele.ReplyButton{UsersBtn("Users", &tele.ReplyRecipient{ID: 1, Quantity: 3}), ChatBtn("Chat", &tele.ReplyRecipient{ID: 2})},
+		[]tele.ReplyButton{PollBtn("Poll", "")},
+	); err != nil {
+		t.Fatalf("valid keyboard rejected: %v", err)
+	}
+	if _, err := BuildReply([]tele.ReplyButton{UsersBtn("Users", &tele.ReplyRecipient{ID: 1, Quantity: 11})}); !errors.Is(err, ErrInvalidKeyboard) {
+		t.Errorf("quantity 11: got %v", err)
+	}
+	_, err := BuildReply([]tele.ReplyButton{
+		UsersBtn("A", &tele.ReplyRecipient{ID: 1}),
+		ChatBtn("B", &tele.ReplyRecipient{ID: 1}),
+	})
+	if !errors.Is(err, ErrInvalidKeyboard) {
+		t.Fatalf("duplicate request id: got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package keyboard
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
+	"github.com/m3rciful/gobot/core/telegram/state"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	noopPayload  = "-"
+	blankLabel   = " "
+	blockedLabel = "·"
+	monthLayout  = "200601"
+	dayLayout    = "20060102"
+)
+
+// DateRange is an inclusive range of dates picked with a range calendar.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Calendar is an inline month calendar with ◀ ▶ navigation. Set OnDate to
+// pick a single day or OnRange to pick two days forming a range.
+type Calendar struct {
+	// Key is the callback key; it must be unique per calendar.
+	Key string
+	// Min and Max bound selectable days (inclusive); zero values are unbounded.
+	Min time.Time
+	Max time.Time
+	// SundayFirst starts weeks on Sunday instead of Monday.
+	SundayFirst bool
+	// Location is used for picked dates; defaults to time.Local.
+	Location *time.Location
+
+	OnDate  func(c tele.Context, date time.Time) error
+	OnRange func(c tele.Context, r DateRange) error
+}
+
+// NewCalendar validates cal and registers its callback.
+func NewCalendar(reg CallbackRegistrar, cal Calendar) (*Calendar, error) {
+	if cal.Key == "" || (cal.OnDate == nil) == (cal.OnRange == nil) {
+		return nil, errors.New("keyboard: calendar requires Key and exactly one of OnDate or OnRange")
+	}
+	if cal.Location == nil {
+		cal.Location = time.Local
+	}
+	cal.Min, cal.Max = dateOnly(cal.Min, cal.Location), dateOnly(cal.Max, cal.Location)
+	p := &cal
+	if err := reg.RegisterCallback(cal.Key, p.handle); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Send posts text with the calendar for month (zero means the current month).
+func (cal *Calendar) Send(c tele.Context, text string, month time.Time) error {
+	return tghelpers.SendText(c, text, &tele.SendOptions{ReplyMarkup: cal.Markup(c, month)})
+}
+
+// Markup renders the calendar for month (zero means the current month).
+func (cal *Calendar) Markup(c tele.Context, month time.Time) *tele.ReplyMarkup {
+	if month.IsZero() {
+		month = time.Now().In(cal.Location)
+	}
+	return cal.render(c, month, time.Time{})
+}
+
+func (cal *Calendar) render(c tele.Context, month, start time.Time) *tele.ReplyMarkup {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, cal.Location)
+	last := first.AddDate(0, 1, -1)
+	suffix := ""
+	if !start.IsZero() {
+		suffix = "." + start.Format(dayLayout)
+	}
+
+	markup := &tele.ReplyMarkup{}
+	noop := func(label string) tele.Btn { return markup.Data(label, cal.Key, noopPayload) }
+
+	prev, next := noop(blankLabel), noop(blankLabel)
+	if cal.Min.IsZero() || !first.AddDate(0, 0, -1).Before(cal.Min) {
+		prev = markup.Data("◀", cal.Key, "m"+first.AddDate(0, -1, 0).Format(monthLayout)+suffix)
+	}
+	if cal.Max.IsZero() || !last.AddDate(0, 0, 1).After(cal.Max) {
+		next = markup.Data("▶", cal.Key, "m"+first.AddDate(0, 1, 0).Format(monthLayout)+suffix)
+	}
+	months := localizedNames(c, i18n.KeyCalendarMonths, 12)
+	rows := [][]tele.Btn{{prev, noop(months[first.Month()-1] + " " + strconv.Itoa(first.Year())), next}}
+
+	weekdays := localizedNames(c, i18n.KeyCalendarWeekdays, 7)
+	if cal.SundayFirst {
+		weekdays = append(weekdays[6:], weekdays[:6]...)
+	}
+	header := make([]tele.Btn, 7)
+	for i, name := range weekdays {
+		header[i] = noop(name)
+	}
+	rows = append(rows, header)
+
+	week := make([]tele.Btn, 0, 7)
+	for i := 0; i < cal.column(first); i++ {
+		week = append(week, noop(blankLabel))
+	}
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		label := strconv.Itoa(day.Day())
+		switch {
+		case !cal.allowed(day):
+			week = append(week, noop(blockedLabel))
+		case day.Equal(start):
+			week = append(week, markup.Data("•"+label+"•", cal.Key, "d"+day.Format(dayLayout)+suffix))
+		default:
+			week = append(week, markup.Data(label, cal.Key, "d"+day.Format(dayLayout)+suffix))
+		}
+		if len(week) == 7 {
+			rows = append(rows, week)
+			week = make([]tele.Btn, 0, 7)
+		}
+	}
+	if len(week) > 0 {
+		for len(week) < 7 {
+			week = append(week, noop(blankLabel))
+		}
+		rows = append(rows, week)
+	}
+	markup.InlineKeyboard = ToInlineKeyboard(rows)
+	return markup
+}
+
+// handle processes "m<yyyymm>[.<start>]" navigation and "d<yyyymmdd>[.<start>]" picks.
+func (cal *Calendar) handle(c tele.Context) error {
+	payload := callbacks.CallbackPayload(c)
+	if payload == "" || payload == noopPayload {
+		return nil
+	}
+	value, startRaw, _ := strings.Cut(payload[1:], ".")
+	var start time.Time
+	if startRaw != "" {
+		var err error
+		// The range start round-trips through the client, so re-check it.
+		if start, err = time.ParseInLocation(dayLayout, startRaw, cal.Location); err != nil || !cal.allowed(start) {
+			return nil
+		}
+	}
+	switch payload[0] {
+	case 'm':
+		month, err := time.ParseInLocation(monthLayout, value, cal.Location)
+		if err != nil {
+			return nil
+		}
+		return c.Edit(cal.render(c, month, start))
+	case 'd':
+		day, err := time.ParseInLocation(dayLayout, value, cal.Location)
+		if err != nil || !cal.allowed(day) {
+			return nil
+		}
+		if cal.OnDate != nil {
+			return cal.OnDate(c, day)
+		}
+		if start.IsZero() {
+			return c.Edit(cal.render(c, day, day))
+		}
+		if day.Before(start) {
+			start, day = day, start
+		}
+		return cal.OnRange(c, DateRange{From: start, To: day})
+	}
+	return nil
+}
+
+func (cal *Calendar) allowed(day time.Time) bool {
+	return (cal.Min.IsZero() || !day.Before(cal.Min)) && (cal.Max.IsZero() || !day.After(cal.Max))
+}
+
+// column returns the weekday column of day (0-6) honouring SundayFirst.
+func (cal *Calendar) column(day time.Time) int {
+	if cal.SundayFirst {
+		return int(day.Weekday())
+	}
+	return (int(day.Weekday()) + 6) % 7
+}
+
+func dateOnly(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// localizedNames splits a space-separated catalog entry, falling back to the
+// builtin English names when a translation has the wrong number of entries.
+func localizedNames(c tele.Context, key string, n int) []string {
+	if names := strings.Fields(i18n.T(c, key)); len(names) == n {
+		return names
+	}
+	return strings.Fields(i18n.Builtin(key))
+}
+
+// ToFSM returns a picker callback that stores the picked value in the FSM
+// session under key and runs the handler of the user's current state.
+func ToFSM[T any](mgr state.Manager, key string) func(c tele.Context, value T) error {
+	return func(c tele.Context, value T) error {
+		mgr.SetTemp(c.Sender().ID, key, value)
+		return mgr.ManagerHandler(c)
+	}
+}
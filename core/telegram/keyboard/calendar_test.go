@@ -0,0 +1,126 @@
+package keyboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// apiCalls records Bot API methods called through a stub server.
+type apiCalls struct {
+	mu      sync.Mutex
+	methods []string
+}
+
+func (a *apiCalls) list() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.methods...)
+}
+
+// callbackContext builds a callback update for key|payload from userID
+// against a stub Bot API that accepts every call.
+func callbackContext(t *testing.T, userID int64, key, payload string) (tele.Context, *apiCalls) {
+	t.Helper()
+	calls := &apiCalls{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.mu.Lock()
+		calls.methods = append(calls.methods, path.Base(r.URL.Path))
+		calls.mu.Unlock()
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	bot, err := tele.NewBot(tele.Settings{URL: srv.URL, Token: "test", Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &tele.User{ID: userID}
+	return bot.NewContext(tele.Update{Callback: &tele.Callback{
+		ID:     "1",
+		Sender: user,
+		Data:   key + "|" + payload,
+		Message: &tele.Message{
+			ID:     1,
+			Sender: user,
+			Chat:   &tele.Chat{ID: userID, Type: tele.ChatPrivate},
+		},
+	}}), calls
+}
+
+type registrar map[string]tele.HandlerFunc
+
+func (r registrar) RegisterCallback(key string, h tele.HandlerFunc, _ ...tele.MiddlewareFunc) error {
+	r[key] = h
+	return nil
+}
+
+func TestCalendarPickBounds(t *testing.T) {
+	var picked []time.Time
+	reg := registrar{}
+	cal, err := NewCalendar(reg, Calendar{
+		Key:      "cal",
+		Min:      time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		Max:      time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC),
+		Location: time.UTC,
+		OnDate: func(_ tele.Context, d time.Time) error {
+			picked = append(picked, d)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, payload := range []string{"d20260309", "d20260321", "d2026031", "x20260315", "-", "d20260315"} {
+		c, _ := callbackContext(t, 1, cal.Key, payload)
+		if err := reg[cal.Key](c); err != nil {
+			t.Fatalf("%q: %v", payload, err)
+		}
+	}
+	if len(picked) != 1 || !picked[0].Equal(time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("picked = %v, want only 2026-03-15", picked)
+	}
+
+	c, calls := callbackContext(t, 1, cal.Key, "m202604")
+	if err := reg[cal.Key](c); err != nil {
+		t.Fatal(err)
+	}
+	if got := calls.list(); len(got) != 1 || got[0] != "editMessageReplyMarkup" {
+		t.Fatalf("navigation calls = %v", got)
+	}
+}
+
+func TestCalendarRangeRejectsForgedStart(t *testing.T) {
+	var ranges []DateRange
+	reg := registrar{}
+	cal, err := NewCalendar(reg, Calendar{
+		Key:      "range",
+		Min:      time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		Max:      time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC),
+		Location: time.UTC,
+		OnRange: func(_ tele.Context, r DateRange) error {
+			ranges = append(ranges, r)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, payload := range []string{"d20260315.20260101", "d20260315.bad", "d20260312.20260318"} {
+		c, _ := callbackContext(t, 1, cal.Key, payload)
+		if err := reg[cal.Key](c); err != nil {
+			t.Fatalf("%q: %v", payload, err)
+		}
+	}
+	want := DateRange{
+		From: time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC),
+	}
+	if len(ranges) != 1 || !ranges[0].From.Equal(want.From) || !ranges[0].To.Equal(want.To) {
+		t.Fatalf("ranges = %v, want only %v", ranges, want)
+	}
+}
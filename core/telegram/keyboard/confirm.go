@@ -0,0 +1,168 @@
+package keyboard
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	defaultConfirmKey     = "confirm"
+	defaultConfirmTimeout = 5 * time.Minute
+)
+
+// ConfirmOptions configures a Confirmer.
+type ConfirmOptions struct {
+	// Key is the callback key (default "confirm").
+	Key string
+	// Timeout bounds how long a prompt stays answerable (default 5 minutes).
+	Timeout time.Duration
+}
+
+// Confirm describes a single yes/no prompt.
+type Confirm struct {
+	Text      string
+	ParseMode tele.ParseMode
+	// YesText and NoText default to the localized "✅ Yes" / "❌ No".
+	YesText string
+	NoText  string
+	// OnYes runs once when the asking user confirms; OnNo (optional) when they decline.
+	OnYes func(c tele.Context) error
+	OnNo  func(c tele.Context) error
+	// OnTimeout (optional) runs when the prompt expires unanswered.
+	OnTimeout func()
+	// DeletePrompt removes the prompt after a decision; otherwise it is edited
+	// to show the outcome without buttons.
+	DeletePrompt bool
+}
+
+type pendingConfirm struct {
+	q      Confirm
+	userID int64
+	timer  *time.Timer
+}
+
+// Confirmer sends yes/no prompts bound to one-shot continuations. Each prompt
+// accepts a single decision from the user who triggered it; later presses,
+// presses by others and presses after the timeout are ignored.
+type Confirmer struct {
+	key     string
+	timeout time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*pendingConfirm
+}
+
+// NewConfirmer registers the confirmation callback.
+func NewConfirmer(reg CallbackRegistrar, opts ConfirmOptions) (*Confirmer, error) {
+	if opts.Key == "" {
+		opts.Key = defaultConfirmKey
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultConfirmTimeout
+	}
+	cf := &Confirmer{key: opts.Key, timeout: opts.Timeout, pending: make(map[string]*pendingConfirm)}
+	if err := reg.RegisterCallback(cf.key, cf.handle); err != nil {
+		return nil, err
+	}
+	return cf, nil
+}
+
+// Ask sends the prompt to the current chat.
+func (cf *Confirmer) Ask(c tele.Context, q Confirm) error {
+	if q.OnYes == nil || c.Sender() == nil {
+		return errors.New("keyboard: confirm requires OnYes and a sender")
+	}
+	id, err := confirmID()
+	if err != nil {
+		return err
+	}
+	p := &pendingConfirm{q: q, userID: c.Sender().ID}
+	cf.mu.Lock()
+	cf.pending[id] = p
+	p.timer = time.AfterFunc(cf.timeout, func() {
+		if cf.take(id) != nil && q.OnTimeout != nil {
+			q.OnTimeout()
+		}
+	})
+	cf.mu.Unlock()
+
+	yes, no := q.YesText, q.NoText
+	if yes == "" {
+		yes = i18n.T(c, i18n.KeyConfirmYes)
+	}
+	if no == "" {
+		no = i18n.T(c, i18n.KeyConfirmNo)
+	}
+	markup := &tele.ReplyMarkup{}
+	markup.Inline(markup.Row(
+		markup.Data(yes, cf.key, id+":y"),
+		markup.Data(no, cf.key, id+":n"),
+	))
+	return tghelpers.SendText(c, q.Text, &tele.SendOptions{ParseMode: q.ParseMode, ReplyMarkup: markup})
+}
+
+// take removes and returns the pending prompt so it can be decided only once.
+func (cf *Confirmer) take(id string) *pendingConfirm {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	p, ok := cf.pending[id]
+	if !ok {
+		return nil
+	}
+	delete(cf.pending, id)
+	p.timer.Stop()
+	return p
+}
+
+func (cf *Confirmer) handle(c tele.Context) error {
+	id, answer, ok := strings.Cut(callbacks.CallbackPayload(c), ":")
+	if !ok || c.Sender() == nil {
+		return nil
+	}
+	cf.mu.Lock()
+	p, found := cf.pending[id]
+	owner := found && p.userID == c.Sender().ID
+	cf.mu.Unlock()
+	if !found {
+		return c.Edit(i18n.T(c, i18n.KeyConfirmExpired))
+	}
+	if !owner {
+		return nil
+	}
+	if p = cf.take(id); p == nil {
+		return nil
+	}
+
+	outcome, next := i18n.KeyConfirmDeclined, p.q.OnNo
+	if answer == "y" {
+		outcome, next = i18n.KeyConfirmAccepted, p.q.OnYes
+	}
+	var err error
+	if p.q.DeletePrompt {
+		err = c.Delete()
+	} else {
+		err = c.Edit(p.q.Text+"\n\n"+i18n.T(c, outcome), &tele.SendOptions{ParseMode: p.q.ParseMode})
+	}
+	if next != nil {
+		return next(c)
+	}
+	return err
+}
+
+func confirmID() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,91 @@
+package keyboard
+
+import (
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// pendingID returns the only pending prompt id.
+func pendingID(t *testing.T, cf *Confirmer) string {
+	t.Helper()
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	if len(cf.pending) != 1 {
+		t.Fatalf("%d pending prompts, want 1", len(cf.pending))
+	}
+	for id := range cf.pending {
+		return id
+	}
+	return ""
+}
+
+func TestConfirmDecidesOnce(t *testing.T) {
+	reg := registrar{}
+	cf, err := NewConfirmer(reg, ConfirmOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var yes, no int
+	c, _ := callbackContext(t, 1, cf.key, "")
+	if err := cf.Ask(c, Confirm{
+		Text:  "Delete?",
+		OnYes: func(tele.Context) error { yes++; return nil },
+		OnNo:  func(tele.Context) error { no++; return nil },
+	}); err != nil {
+		t.Fatal(err)
+	}
+	id := pendingID(t, cf)
+
+	c, calls := callbackContext(t, 2, cf.key, id+":y")
+	if err := reg[cf.key](c); err != nil || len(calls.list()) != 0 {
+		t.Fatalf("other user: err %v, calls %v", err, calls.list())
+	}
+	for _, payload := range []string{id + ":y", id + ":n"} {
+		c, _ = callbackContext(t, 1, cf.key, payload)
+		if err := reg[cf.key](c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if yes != 1 || no != 0 {
+		t.Fatalf("yes = %d, no = %d; want one confirmation", yes, no)
+	}
+
+	c, calls = callbackContext(t, 1, cf.key, "malformed")
+	if err := reg[cf.key](c); err != nil || len(calls.list()) != 0 {
+		t.Fatalf("malformed payload: err %v, calls %v", err, calls.list())
+	}
+}
+
+func TestConfirmTimeout(t *testing.T) {
+	reg := registrar{}
+	cf, err := NewConfirmer(reg, ConfirmOptions{Timeout: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	timedOut := make(chan struct{})
+	yes := 0
+	c, _ := callbackContext(t, 1, cf.key, "")
+	if err := cf.Ask(c, Confirm{
+		Text:      "Delete?",
+		OnYes:     func(tele.Context) error { yes++; return nil },
+		OnTimeout: func() { close(timedOut) },
+	}); err != nil {
+		t.Fatal(err)
+	}
+	id := pendingID(t, cf)
+	select {
+	case <-timedOut:
+	case <-time.After(time.Second):
+		t.Fatal("OnTimeout not called")
+	}
+
+	c, calls := callbackContext(t, 1, cf.key, id+":y")
+	if err := reg[cf.key](c); err != nil {
+		t.Fatal(err)
+	}
+	if yes != 0 || len(calls.list()) != 1 {
+		t.Fatalf("late press: yes = %d, calls %v", yes, calls.list())
+	}
+}
@@ -1,15 +1,28 @@
 package keyboard
 
-import tele "gopkg.in/telebot.v4"
+import (
+	"github.com/m3rciful/gobot/core/telegram/i18n"
+
+	tele "gopkg.in/telebot.v4"
+)
 
 // InlineBtn describes a convenience wrapper for inline button properties.
+// Callback buttons set Unique and Data; every other kind sets exactly one of
+// the remaining action fields (see the URLBtn, WebAppBtn, ... builders).
 type InlineBtn struct {
 	Text   string
 	Unique string
 	Data   string
-}
 
-const defaultCancelButtonText = "❌ Cancel"
+	URL                string
+	WebApp             *tele.WebApp
+	Login              *tele.Login
+	SwitchInline       *string
+	SwitchInlineHere   *string
+	SwitchInlineChosen *tele.SwitchInlineQuery
+	CopyText           string
+	Pay                bool
+}
 
 // ForceReply returns a markup that forces the user to reply.
 func ForceReply() *tele.ReplyMarkup {
@@ -46,13 +59,14 @@ func InlineButtons(buttons []InlineBtn) *tele.ReplyMarkup {
 }
 
 // InlineButtonsRows builds an inline keyboard from rows of InlineBtn.
+// Use BuildInline to also check Telegram's constraints.
 func InlineButtonsRows(rows ...[]InlineBtn) *tele.ReplyMarkup {
 	markup := &tele.ReplyMarkup{}
 	inline := make([][]tele.InlineButton, len(rows))
 	for i, row := range rows {
 		r := make([]tele.InlineButton, len(row))
 		for j, btn := range row {
-			r[j] = *markup.Data(btn.Text, btn.Unique, btn.Data).Inline()
+			r[j] = btn.Inline()
 		}
 		inline[i] = r
 	}
@@ -117,13 +131,35 @@ func CancelButton(markup *tele.ReplyMarkup, action string, options ...string) te
 	if len(options) > 0 && options[0] != "" {
 		payload = options[0]
 	}
-	text := defaultCancelButtonText
+	bundle := i18n.CurrentBundle()
+	text := bundle.Translate(bundle.DefaultLocale(), i18n.KeyCancel)
 	if len(options) > 1 && options[1] != "" {
 		text = options[1]
 	}
 	return markup.Data(text, action, payload)
 }
 
+// CancelButtonFor behaves like CancelButton but labels the button in the locale of the current update.
+func CancelButtonFor(c tele.Context, markup *tele.ReplyMarkup, action string, options ...string) tele.Btn {
+	payload := ""
+	if len(options) > 0 {
+		payload = options[0]
+	}
+	text := i18n.T(c, i18n.KeyCancel)
+	if len(options) > 1 && options[1] != "" {
+		text = options[1]
+	}
+	return CancelButton(markup, action, payload, text)
+}
+
+// SingleCancelMarkupFor creates an inline keyboard with a single localized cancel button.
+func SingleCancelMarkupFor(c tele.Context, action string, options ...string) *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{}
+	btn := CancelButtonFor(c, markup, action, options...)
+	markup.InlineKeyboard = [][]tele.InlineButton{{*btn.Inline()}}
+	return markup
+}
+
 // SingleCancelMarkup creates an inline keyboard with a single cancel button.
 func SingleCancelMarkup(action string, options ...string) *tele.ReplyMarkup {
 	markup := &tele.ReplyMarkup{}
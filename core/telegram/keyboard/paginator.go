@@ -0,0 +1,136 @@
+package keyboard
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const defaultPageSize = 8
+
+// CallbackRegistrar registers callback handlers; *telegram.Registry implements it.
+type CallbackRegistrar interface {
+	RegisterCallback(key string, handler tele.HandlerFunc, mws ...tele.MiddlewareFunc) error
+}
+
+// Page describes the page being rendered.
+type Page struct {
+	// Index is zero-based.
+	Index int
+	Pages int
+	Total int
+}
+
+// PageSource returns the items in [offset, offset+limit) and the total item count.
+type PageSource func(c tele.Context, offset, limit int) (items []InlineBtn, total int, err error)
+
+// Paginator renders a page of item buttons followed by ◀ n/N ▶ controls.
+type Paginator struct {
+	// Key is the navigation callback key; it must be unique per paginator.
+	Key    string
+	Source PageSource
+	// PageSize defaults to 8; PerRow (default 1) lays out item buttons.
+	PageSize int
+	PerRow   int
+	// Text renders the message text; defaults to the localized "Page n of N".
+	Text      func(c tele.Context, p Page) string
+	ParseMode tele.ParseMode
+}
+
+// NewPaginator validates p and registers its navigation callback, which
+// edits the message in place.
+func NewPaginator(reg CallbackRegistrar, p Paginator) (*Paginator, error) {
+	if p.Key == "" || p.Source == nil {
+		return nil, errors.New("keyboard: paginator requires Key and Source")
+	}
+	if p.PageSize <= 0 {
+		p.PageSize = defaultPageSize
+	}
+	if p.PerRow <= 0 {
+		p.PerRow = 1
+	}
+	pg := &p
+	if err := reg.RegisterCallback(p.Key, pg.navigate); err != nil {
+		return nil, err
+	}
+	return pg, nil
+}
+
+// Send posts the given page as a new message.
+func (p *Paginator) Send(c tele.Context, page int) error {
+	text, markup, err := p.Render(c, page)
+	if err != nil {
+		return err
+	}
+	return tghelpers.SendText(c, text, &tele.SendOptions{ParseMode: p.ParseMode, ReplyMarkup: markup})
+}
+
+// Render builds the text and keyboard for page, clamped to the valid range.
+func (p *Paginator) Render(c tele.Context, page int) (string, *tele.ReplyMarkup, error) {
+	if page < 0 {
+		page = 0
+	}
+	items, total, err := p.Source(c, page*p.PageSize, p.PageSize)
+	if err != nil {
+		return "", nil, err
+	}
+	pages := (total + p.PageSize - 1) / p.PageSize
+	if pages > 0 && page >= pages {
+		page = pages - 1
+		if items, total, err = p.Source(c, page*p.PageSize, p.PageSize); err != nil {
+			return "", nil, err
+		}
+	}
+	info := Page{Index: page, Pages: pages, Total: total}
+
+	var text string
+	switch {
+	case p.Text != nil:
+		text = p.Text(c, info)
+	case total == 0:
+		text = i18n.T(c, i18n.KeyPageEmpty)
+	default:
+		text = i18n.T(c, i18n.KeyPageTitle, i18n.Args{"page": page + 1, "pages": pages})
+	}
+
+	markup := &tele.ReplyMarkup{}
+	var rows [][]tele.InlineButton
+	for i := 0; i < len(items); i += p.PerRow {
+		row := make([]tele.InlineButton, 0, p.PerRow)
+		for _, it := range items[i:min(i+p.PerRow, len(items))] {
+			row = append(row, it.Inline())
+		}
+		rows = append(rows, row)
+	}
+	if pages > 1 {
+		var nav []tele.Btn
+		if page > 0 {
+			nav = append(nav, markup.Data("◀", p.Key, strconv.Itoa(page-1)))
+		}
+		nav = append(nav, markup.Data(strconv.Itoa(page+1)+"/"+strconv.Itoa(pages), p.Key, "-"))
+		if page < pages-1 {
+			nav = append(nav, markup.Data("▶", p.Key, strconv.Itoa(page+1)))
+		}
+		rows = append(rows, ToInlineKeyboard([][]tele.Btn{nav})...)
+	}
+	markup.InlineKeyboard = rows
+	return text, markup, nil
+}
+
+// navigate handles ◀/▶ presses; the middle page button carries "-" and is ignored.
+func (p *Paginator) navigate(c tele.Context) error {
+	page, err := callbacks.PayloadInt(c)
+	if err != nil {
+		return nil
+	}
+	text, markup, err := p.Render(c, page)
+	if err != nil {
+		return err
+	}
+	return c.Edit(text, &tele.SendOptions{ParseMode: p.ParseMode, ReplyMarkup: markup})
+}
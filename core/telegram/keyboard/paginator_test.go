@@ -0,0 +1,97 @@
+package keyboard
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func numberSource(n int) PageSource {
+	return func(_ tele.Context, offset, limit int) ([]InlineBtn, int, error) {
+		var items []InlineBtn
+		for i := offset; i < min(offset+limit, n); i++ {
+			items = append(items, DataBtn(strconv.Itoa(i), "item", strconv.Itoa(i)))
+		}
+		return items, n, nil
+	}
+}
+
+func TestPaginatorRenderClampsPages(t *testing.T) {
+	var seen []Page
+	p, err := NewPaginator(registrar{}, Paginator{
+		Key:      "list",
+		Source:   numberSource(7),
+		PageSize: 3,
+		PerRow:   2,
+		Text: func(_ tele.Context, pg Page) string {
+			seen = append(seen, pg)
+			return ""
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		page  int
+		index int
+		items int
+		nav   int
+	}{
+		{-1, 0, 3, 2},
+		{1, 1, 3, 3},
+		{9, 2, 1, 2},
+	}
+	for _, tc := range cases {
+		_, markup, err := p.Render(nil, tc.page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		last := seen[len(seen)-1]
+		if last.Index != tc.index || last.Pages != 3 || last.Total != 7 {
+			t.Errorf("page %d: rendered %+v", tc.page, last)
+		}
+		rows := markup.InlineKeyboard
+		items := 0
+		for _, row := range rows[:len(rows)-1] {
+			items += len(row)
+		}
+		if items != tc.items || len(rows[len(rows)-1]) != tc.nav {
+			t.Errorf("page %d: %d items, %d nav buttons", tc.page, items, len(rows[len(rows)-1]))
+		}
+	}
+}
+
+func TestPaginatorNavigate(t *testing.T) {
+	reg := registrar{}
+	p, err := NewPaginator(reg, Paginator{Key: "list", Source: numberSource(20), Text: func(tele.Context, Page) string { return "page" }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, calls := callbackContext(t, 1, p.Key, "-")
+	if err := reg[p.Key](c); err != nil || len(calls.list()) != 0 {
+		t.Fatalf("middle button: err %v, calls %v", err, calls.list())
+	}
+	c, calls = callbackContext(t, 1, p.Key, "1")
+	if err := reg[p.Key](c); err != nil {
+		t.Fatal(err)
+	}
+	if got := calls.list(); len(got) != 1 || got[0] != "editMessageText" {
+		t.Fatalf("navigate calls = %v", got)
+	}
+
+	failing, err := NewPaginator(registrar{}, Paginator{
+		Key: "broken",
+		Source: func(tele.Context, int, int) ([]InlineBtn, int, error) {
+			return nil, 0, errors.New("db down")
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := failing.Render(nil, 0); err == nil {
+		t.Fatal("source error not returned")
+	}
+}
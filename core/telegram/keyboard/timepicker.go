@@ -0,0 +1,122 @@
+package keyboard
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const defaultMinuteStep = 15
+
+// TimePicker is an inline hour grid followed by a minute grid for the chosen
+// hour. The picked time is delivered as the offset from midnight.
+type TimePicker struct {
+	// Key is the callback key; it must be unique per picker.
+	Key string
+	// Step is the minute granularity (default 15); it should divide 60.
+	Step int
+	// Min and Max bound selectable times of day (inclusive); zero Max means 23:59.
+	Min time.Duration
+	Max time.Duration
+
+	OnTime func(c tele.Context, t time.Duration) error
+}
+
+// NewTimePicker validates p and registers its callback.
+func NewTimePicker(reg CallbackRegistrar, p TimePicker) (*TimePicker, error) {
+	if p.Key == "" || p.OnTime == nil {
+		return nil, errors.New("keyboard: time picker requires Key and OnTime")
+	}
+	if p.Step <= 0 || p.Step > 60 {
+		p.Step = defaultMinuteStep
+	}
+	if p.Max <= 0 || p.Max >= 24*time.Hour {
+		p.Max = 24*time.Hour - time.Minute
+	}
+	tp := &p
+	if err := reg.RegisterCallback(p.Key, tp.handle); err != nil {
+		return nil, err
+	}
+	return tp, nil
+}
+
+// Send posts text with the hour grid.
+func (p *TimePicker) Send(c tele.Context, text string) error {
+	return tghelpers.SendText(c, text, &tele.SendOptions{ReplyMarkup: p.Markup()})
+}
+
+// Markup renders the hour grid, six hours per row.
+func (p *TimePicker) Markup() *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{}
+	btns := make([]tele.Btn, 0, 24)
+	for h := 0; h < 24; h++ {
+		label := fmt.Sprintf("%02d", h)
+		if p.hourAllowed(h) {
+			btns = append(btns, markup.Data(label, p.Key, "h"+strconv.Itoa(h)))
+		} else {
+			btns = append(btns, markup.Data(blockedLabel, p.Key, noopPayload))
+		}
+	}
+	markup.InlineKeyboard = ToInlineKeyboard(ChunkButtons(btns, 6))
+	return markup
+}
+
+func (p *TimePicker) minutesMarkup(hour int) *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{}
+	var btns []tele.Btn
+	for m := 0; m < 60; m += p.Step {
+		t := time.Duration(hour)*time.Hour + time.Duration(m)*time.Minute
+		if p.allowed(t) {
+			btns = append(btns, markup.Data(fmt.Sprintf("%02d:%02d", hour, m), p.Key, "t"+strconv.Itoa(int(t/time.Minute))))
+		}
+	}
+	rows := ChunkButtons(btns, 4)
+	rows = append(rows, []tele.Btn{markup.Data("◀", p.Key, "b")})
+	markup.InlineKeyboard = ToInlineKeyboard(rows)
+	return markup
+}
+
+// handle processes "h<hour>" (open minutes), "t<minutes>" (pick) and "b" (back to hours).
+func (p *TimePicker) handle(c tele.Context) error {
+	payload := callbacks.CallbackPayload(c)
+	if payload == "" || payload == noopPayload {
+		return nil
+	}
+	switch payload[0] {
+	case 'b':
+		return c.Edit(p.Markup())
+	case 'h':
+		hour, err := strconv.Atoi(payload[1:])
+		if err != nil || hour < 0 || hour > 23 || !p.hourAllowed(hour) {
+			return nil
+		}
+		return c.Edit(p.minutesMarkup(hour))
+	case 't':
+		minutes, err := strconv.Atoi(payload[1:])
+		t := time.Duration(minutes) * time.Minute
+		if err != nil || !p.allowed(t) {
+			return nil
+		}
+		return p.OnTime(c, t)
+	}
+	return nil
+}
+
+func (p *TimePicker) allowed(t time.Duration) bool {
+	return t >= p.Min && t <= p.Max
+}
+
+func (p *TimePicker) hourAllowed(hour int) bool {
+	for m := 0; m < 60; m += p.Step {
+		if p.allowed(time.Duration(hour)*time.Hour + time.Duration(m)*time.Minute) {
+			return true
+		}
+	}
+	return false
+}
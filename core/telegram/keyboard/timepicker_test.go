@@ -0,0 +1,49 @@
+package keyboard
+
+import (
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestTimePickerBounds(t *testing.T) {
+	var picked []time.Duration
+	reg := registrar{}
+	p, err := NewTimePicker(reg, TimePicker{
+		Key:  "time",
+		Step: 30,
+		Min:  9 * time.Hour,
+		Max:  17*time.Hour + 30*time.Minute,
+		OnTime: func(_ tele.Context, d time.Duration) error {
+			picked = append(picked, d)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, payload := range []string{"t539", "t1051", "tx", "h8", "h24", "h-1", "t600"} {
+		c, calls := callbackContext(t, 1, p.Key, payload)
+		if err := reg[p.Key](c); err != nil {
+			t.Fatalf("%q: %v", payload, err)
+		}
+		if got := calls.list(); len(got) != 0 {
+			t.Fatalf("%q: unexpected calls %v", payload, got)
+		}
+	}
+	if len(picked) != 1 || picked[0] != 10*time.Hour {
+		t.Fatalf("picked = %v, want [10h0m0s]", picked)
+	}
+
+	c, calls := callbackContext(t, 1, p.Key, "h17")
+	if err := reg[p.Key](c); err != nil {
+		t.Fatal(err)
+	}
+	if got := calls.list(); len(got) != 1 {
+		t.Fatalf("hour calls = %v", got)
+	}
+	if got := len(p.minutesMarkup(17).InlineKeyboard[0]); got != 2 {
+		t.Fatalf("17:xx offers %d minutes, want 2", got)
+	}
+}
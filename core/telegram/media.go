@@ -0,0 +1,100 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/telegram/middleware"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// MediaKind names a non-text message kind that can be routed via the Registry.
+type MediaKind string
+
+// Supported media kinds.
+const (
+	MediaPhoto      MediaKind = "photo"
+	MediaVoice      MediaKind = "voice"
+	MediaVideo      MediaKind = "video"
+	MediaVideoNote  MediaKind = "video_note"
+	MediaAudio      MediaKind = "audio"
+	MediaAnimation  MediaKind = "animation"
+	MediaSticker    MediaKind = "sticker"
+	MediaDocument   MediaKind = "document"
+	MediaLocation   MediaKind = "location"
+	MediaVenue      MediaKind = "venue"
+	MediaContact    MediaKind = "contact"
+	MediaPoll       MediaKind = "poll"
+	MediaDice       MediaKind = "dice"
+	MediaWebAppData MediaKind = "web_app_data"
+)
+
+var mediaEndpoints = map[MediaKind]string{
+	MediaPhoto:      tele.OnPhoto,
+	MediaVoice:      tele.OnVoice,
+	MediaVideo:      tele.OnVideo,
+	MediaVideoNote:  tele.OnVideoNote,
+	MediaAudio:      tele.OnAudio,
+	MediaAnimation:  tele.OnAnimation,
+	MediaSticker:    tele.OnSticker,
+	MediaDocument:   tele.OnDocument,
+	MediaLocation:   tele.OnLocation,
+	MediaVenue:      tele.OnVenue,
+	MediaContact:    tele.OnContact,
+	MediaPoll:       tele.OnPoll,
+	MediaDice:       tele.OnDice,
+	MediaWebAppData: tele.OnWebApp,
+}
+
+// MediaKinds lists all supported kinds in a stable order.
+func MediaKinds() []MediaKind {
+	return []MediaKind{
+		MediaPhoto, MediaVoice, MediaVideo, MediaVideoNote, MediaAudio, MediaAnimation, MediaSticker,
+		MediaDocument, MediaLocation, MediaVenue, MediaContact, MediaPoll, MediaDice, MediaWebAppData,
+	}
+}
+
+// Endpoint returns the telebot endpoint for k, or "" for unknown kinds.
+func (k MediaKind) Endpoint() string {
+	return mediaEndpoints[k]
+}
+
+// RegisterMedia adds the handler for a media kind. Middleware wraps the
+// handler and runs after the registry middleware.
+func (r *Registry) RegisterMedia(kind MediaKind, handler tele.HandlerFunc, mws ...tele.MiddlewareFunc) error {
+	if r == nil || handler == nil || kind.Endpoint() == "" {
+		logger.TWire.LogAttrs(context.Background(), slog.LevelWarn, "register.media.skip",
+			slog.String("kind", string(kind)),
+			slog.Bool("handler_nil", handler == nil),
+		)
+		return errors.New("invalid media registration")
+	}
+	r.mediaMu.Lock()
+	defer r.mediaMu.Unlock()
+	if r.media == nil {
+		r.media = make(map[MediaKind]tele.HandlerFunc)
+	}
+	if _, exists := r.media[kind]; exists {
+		logger.TWire.LogAttrs(context.Background(), slog.LevelWarn, "register.media.duplicate",
+			slog.String("kind", string(kind)),
+		)
+		return fmt.Errorf("media handler already registered: %s", kind)
+	}
+	r.media[kind] = middleware.Chain(handler, mws...)
+	return nil
+}
+
+// MediaHandler returns the handler registered for kind.
+func (r *Registry) MediaHandler(kind MediaKind) (tele.HandlerFunc, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mediaMu.RLock()
+	defer r.mediaMu.RUnlock()
+	h, ok := r.media[kind]
+	return h, ok
+}
@@ -0,0 +1,3 @@
+// Package menu builds nested interactive menus on inline or reply keyboards
+// with back/home navigation, dynamic buttons and checkbox/radio toggles.
+package menu
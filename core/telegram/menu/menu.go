@@ -0,0 +1,407 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	tg "github.com/m3rciful/gobot/core/telegram"
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+	"github.com/m3rciful/gobot/core/telegram/format"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
+	"github.com/m3rciful/gobot/core/telegram/keyboard"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Node is a screen of the menu tree.
+type Node struct {
+	// ID identifies the node in callback data; it must be unique within the
+	// menu and must not contain ":".
+	ID string
+	// Title labels the button that opens the node; TitleKey localizes it.
+	Title    string
+	TitleKey string
+	// Text renders the Markdown (V1) body of the screen; callers escape
+	// untrusted values with format.EscapeMarkdown. Without Text the escaped
+	// title is shown. Button labels are sent as plain text.
+	Text func(c tele.Context) string
+	// Buttons returns dynamic buttons; presses are passed to OnPress.
+	Buttons func(c tele.Context) []Button
+	OnPress func(c tele.Context, data string) error
+	// Checkboxes and Radios are rendered as toggles; the screen is redrawn after a change.
+	Checkboxes []Checkbox
+	Radios     []Radio
+	Children   []*Node
+	// Columns lays out buttons (default 1); navigation stays on its own row.
+	Columns int
+}
+
+// Button is a dynamic button; Data is handed to Node.OnPress.
+type Button struct {
+	Text string
+	Data string
+}
+
+// Checkbox is an on/off toggle.
+type Checkbox struct {
+	ID    string
+	Label string
+	Get   func(c tele.Context) bool
+	Set   func(c tele.Context, on bool) error
+}
+
+// Radio is a group of mutually exclusive options.
+type Radio struct {
+	ID      string
+	Options []Button
+	Get     func(c tele.Context) string
+	Set     func(c tele.Context, value string) error
+}
+
+// Options configures a Menu. Inline payloads longer than callback_data allows
+// need a payload store on the registry (Registry.SetPayloadStore).
+type Options struct {
+	// Key is the callback key; it must be unique per menu.
+	Key  string
+	Root *Node
+	// Reply renders reply keyboards instead of inline ones; the current
+	// node of each user is tracked in memory and presses arrive as text.
+	Reply bool
+	// Idle forgets a reply-mode position after this long without presses
+	// (default 24h).
+	Idle time.Duration
+}
+
+const defaultIdle = 24 * time.Hour
+
+// position is the reply-mode node of a user and when it was last shown.
+type position struct {
+	node string
+	seen time.Time
+}
+
+// Menu is a tree of nodes bound to a callback key.
+type Menu struct {
+	reg     *tg.Registry
+	key     string
+	root    *Node
+	reply   bool
+	nodes   map[string]*Node
+	parents map[string]*Node
+	idle    time.Duration
+
+	mu      sync.Mutex
+	current map[int64]position
+	swept   time.Time
+}
+
+// New indexes the tree and registers the callbacks (or, in reply mode, the
+// text route) that drive it.
+func New(reg *tg.Registry, opts Options) (*Menu, error) {
+	if opts.Key == "" || opts.Root == nil {
+		return nil, errors.New("menu: Key and Root are required")
+	}
+	menu := &Menu{
+		reg:     reg,
+		key:     opts.Key,
+		root:    opts.Root,
+		reply:   opts.Reply,
+		nodes:   make(map[string]*Node),
+		parents: make(map[string]*Node),
+		idle:    opts.Idle,
+		current: make(map[int64]position),
+	}
+	if menu.idle <= 0 {
+		menu.idle = defaultIdle
+	}
+	if err := menu.index(opts.Root, nil); err != nil {
+		return nil, err
+	}
+	if menu.reply {
+		err := reg.RegisterTextRoute(tg.TextRoute{
+			Name:     "menu." + menu.key,
+			Match:    menu.matchText,
+			Priority: 100,
+			Handler:  menu.handleText,
+		})
+		return menu, err
+	}
+	return menu, reg.RegisterCallback(menu.key, menu.handleCallback)
+}
+
+func (m *Menu) index(n *Node, parent *Node) error {
+	if n.ID == "" || strings.Contains(n.ID, ":") {
+		return fmt.Errorf("menu: invalid node id %q", n.ID)
+	}
+	if _, dup := m.nodes[n.ID]; dup {
+		return fmt.Errorf("menu: duplicate node id %q", n.ID)
+	}
+	m.nodes[n.ID] = n
+	if parent != nil {
+		m.parents[n.ID] = parent
+	}
+	for _, child := range n.Children {
+		if err := m.index(child, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Show opens the root node.
+func (m *Menu) Show(c tele.Context) error {
+	return m.Open(c, m.root.ID)
+}
+
+// Open renders node id, editing the current message when the update is a
+// callback and sending a new message otherwise.
+func (m *Menu) Open(c tele.Context, id string) error {
+	n, ok := m.nodes[id]
+	if !ok {
+		return fmt.Errorf("menu: unknown node %q", id)
+	}
+	var text string
+	if n.Text != nil {
+		text = n.Text(c)
+	} else {
+		// The version is valid, so escaping cannot fail.
+		text, _ = format.EscapeMarkdown(m.title(c, n), format.MarkdownV1, "")
+	}
+	if m.reply {
+		if c.Sender() != nil {
+			m.setPosition(c.Sender().ID, n.ID)
+		}
+		return tghelpers.SendMD(c, text, m.replyMarkup(c, n))
+	}
+	markup, err := m.inlineMarkup(c, n)
+	if err != nil {
+		return err
+	}
+	return tghelpers.EditOrSendMD(c, text, markup)
+}
+
+// Close forgets the reply-mode position of the user and removes the keyboard.
+func (m *Menu) Close(c tele.Context, text string) error {
+	if c.Sender() != nil {
+		m.mu.Lock()
+		delete(m.current, c.Sender().ID)
+		m.mu.Unlock()
+	}
+	return tghelpers.SendText(c, text, &tele.SendOptions{ReplyMarkup: keyboard.RemoveKeyboard()})
+}
+
+// item is a rendered button: its label and the action payload it triggers.
+type item struct {
+	label   string
+	payload string
+}
+
+// items lists the buttons of n as rows; the last row holds back/home.
+func (m *Menu) items(c tele.Context, n *Node) [][]item {
+	var flat []item
+	for _, child := range n.Children {
+		flat = append(flat, item{m.title(c, child), "o:" + child.ID})
+	}
+	if n.Buttons != nil {
+		for _, b := range n.Buttons(c) {
+			flat = append(flat, item{b.Text, "p:" + n.ID + ":" + b.Data})
+		}
+	}
+	for _, cb := range n.Checkboxes {
+		mark := "☐ "
+		if cb.Get != nil && cb.Get(c) {
+			mark = "☑ "
+		}
+		flat = append(flat, item{mark + cb.Label, "c:" + n.ID + ":" + cb.ID})
+	}
+	for _, r := range n.Radios {
+		selected := ""
+		if r.Get != nil {
+			selected = r.Get(c)
+		}
+		for _, opt := range r.Options {
+			mark := "○ "
+			if opt.Data == selected {
+				mark = "◉ "
+			}
+			flat = append(flat, item{mark + opt.Text, "r:" + n.ID + ":" + r.ID + ":" + opt.Data})
+		}
+	}
+
+	cols := n.Columns
+	if cols <= 0 {
+		cols = 1
+	}
+	var rows [][]item
+	for i := 0; i < len(flat); i += cols {
+		rows = append(rows, flat[i:min(i+cols, len(flat))])
+	}
+	if parent, ok := m.parents[n.ID]; ok {
+		nav := []item{{i18n.T(c, i18n.KeyMenuBack), "o:" + parent.ID}}
+		if parent != m.root {
+			nav = append(nav, item{i18n.T(c, i18n.KeyMenuHome), "o:" + m.root.ID})
+		}
+		rows = append(rows, nav)
+	}
+	return rows
+}
+
+func (m *Menu) inlineMarkup(c tele.Context, n *Node) (*tele.ReplyMarkup, error) {
+	markup := &tele.ReplyMarkup{}
+	var rows [][]tele.Btn
+	for _, row := range m.items(c, n) {
+		btns := make([]tele.Btn, len(row))
+		for i, it := range row {
+			btn, err := m.reg.DataButton(tghelpers.BuildContext(c), markup, it.label, m.key, it.payload, 0)
+			if err != nil {
+				return nil, fmt.Errorf("menu: button %q: %w", it.label, err)
+			}
+			btns[i] = btn
+		}
+		rows = append(rows, btns)
+	}
+	markup.InlineKeyboard = keyboard.ToInlineKeyboard(rows)
+	return markup, nil
+}
+
+func (m *Menu) replyMarkup(c tele.Context, n *Node) *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{ResizeKeyboard: true}
+	var rows []tele.Row
+	for _, row := range m.items(c, n) {
+		btns := make([]tele.Btn, len(row))
+		for i, it := range row {
+			btns[i] = markup.Text(it.label)
+		}
+		rows = append(rows, markup.Row(btns...))
+	}
+	markup.Reply(rows...)
+	return markup
+}
+
+func (m *Menu) handleCallback(c tele.Context) error {
+	return m.dispatch(c, callbacks.CallbackPayload(c))
+}
+
+// matchText reports whether the text is a button of the user's current reply-mode node.
+func (m *Menu) matchText(c tele.Context) bool {
+	_, ok := m.textPayload(c)
+	return ok
+}
+
+func (m *Menu) handleText(c tele.Context) error {
+	payload, ok := m.textPayload(c)
+	if !ok {
+		return nil
+	}
+	return m.dispatch(c, payload)
+}
+
+func (m *Menu) textPayload(c tele.Context) (string, bool) {
+	if c.Sender() == nil {
+		return "", false
+	}
+	id, ok := m.position(c.Sender().ID)
+	if !ok {
+		return "", false
+	}
+	n, ok := m.nodes[id]
+	if !ok {
+		return "", false
+	}
+	text := strings.TrimSpace(c.Text())
+	for _, row := range m.items(c, n) {
+		for _, it := range row {
+			if it.label == text {
+				return it.payload, true
+			}
+		}
+	}
+	return "", false
+}
+
+// dispatch executes an action payload:
+// o:<node> open, p:<node>:<data> press, c:<node>:<checkbox>, r:<node>:<radio>:<value>.
+func (m *Menu) dispatch(c tele.Context, payload string) error {
+	parts := strings.SplitN(payload, ":", 4)
+	if len(parts) < 2 {
+		return nil
+	}
+	n, ok := m.nodes[parts[1]]
+	if !ok {
+		return nil
+	}
+	switch {
+	case parts[0] == "o":
+		return m.Open(c, n.ID)
+	case parts[0] == "p" && len(parts) >= 3 && n.OnPress != nil:
+		data := strings.Join(parts[2:], ":")
+		if err := n.OnPress(c, data); err != nil {
+			return err
+		}
+	case parts[0] == "c" && len(parts) == 3:
+		for _, cb := range n.Checkboxes {
+			if cb.ID == parts[2] && cb.Set != nil {
+				on := cb.Get != nil && cb.Get(c)
+				if err := cb.Set(c, !on); err != nil {
+					return err
+				}
+			}
+		}
+	case parts[0] == "r" && len(parts) == 4:
+		for _, r := range n.Radios {
+			if r.ID == parts[2] && r.Set != nil {
+				if err := r.Set(c, parts[3]); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		return nil
+	}
+	return m.Open(c, n.ID)
+}
+
+// setPosition records the reply-mode node of userID, sweeping idle
+// positions at most once per idle period.
+func (m *Menu) setPosition(userID int64, node string) {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if now.Sub(m.swept) > m.idle {
+		for id, p := range m.current {
+			if now.Sub(p.seen) > m.idle {
+				delete(m.current, id)
+			}
+		}
+		m.swept = now
+	}
+	m.current[userID] = position{node: node, seen: now}
+}
+
+// position returns the reply-mode node of userID unless it went idle.
+func (m *Menu) position(userID int64) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.current[userID]
+	if !ok {
+		return "", false
+	}
+	if time.Since(p.seen) > m.idle {
+		delete(m.current, userID)
+		return "", false
+	}
+	return p.node, true
+}
+
+func (m *Menu) title(c tele.Context, n *Node) string {
+	if n.TitleKey != "" {
+		if text := i18n.T(c, n.TitleKey); text != n.TitleKey {
+			return text
+		}
+	}
+	return n.Title
+}
@@ -0,0 +1,84 @@
+package menu
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tg "github.com/m3rciful/gobot/core/telegram"
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestOpenReportsOversizedButtons(t *testing.T) {
+	child := &Node{ID: strings.Repeat("n", 70), Title: "Deep"}
+	m, err := New(tg.NewRegistry(), Options{Key: "menu", Root: &Node{ID: "root", Title: "Root", Children: []*Node{child}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot, err := tele.NewBot(tele.Settings{Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := bot.NewContext(tele.Update{Message: &tele.Message{
+		Sender: &tele.User{ID: 1},
+		Chat:   &tele.Chat{ID: 1, Type: tele.ChatPrivate},
+	}})
+	if err := m.Show(c); !errors.Is(err, callbacks.ErrTooLong) {
+		t.Fatalf("Show = %v, want ErrTooLong", err)
+	}
+}
+
+func TestReplyPositionsExpire(t *testing.T) {
+	m, err := New(tg.NewRegistry(), Options{Key: "menu", Root: &Node{ID: "root"}, Reply: true, Idle: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.current[1] = position{node: "root", seen: time.Now().Add(-2 * time.Minute)}
+	m.current[2] = position{node: "root", seen: time.Now()}
+	if _, ok := m.position(1); ok {
+		t.Fatal("idle position still resolved")
+	}
+
+	m.current[3] = position{node: "root", seen: time.Now().Add(-2 * time.Minute)}
+	m.setPosition(4, "root")
+	if _, ok := m.current[3]; ok || len(m.current) != 2 {
+		t.Fatalf("positions after sweep = %v", m.current)
+	}
+}
+
+func TestOpenEscapesTitle(t *testing.T) {
+	var text string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Text string `json:"text"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		text = body.Text
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1}}}`))
+	}))
+	defer api.Close()
+	m, err := New(tg.NewRegistry(), Options{Key: "menu", Root: &Node{ID: "root", Title: "my_settings *beta*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot, err := tele.NewBot(tele.Settings{URL: api.URL, Token: "test", Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := bot.NewContext(tele.Update{Message: &tele.Message{
+		Sender: &tele.User{ID: 1},
+		Chat:   &tele.Chat{ID: 1, Type: tele.ChatPrivate},
+	}})
+	if err := m.Show(c); err != nil {
+		t.Fatal(err)
+	}
+	if want := `my\_settings \*beta\*`; text != want {
+		t.Fatalf("sent %q, want %q", text, want)
+	}
+}
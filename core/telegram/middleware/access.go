@@ -1,37 +1,56 @@
 package middleware
 
-import tele "gopkg.in/telebot.v4"
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/m3rciful/gobot/core/logger"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+	"github.com/m3rciful/gobot/core/telegram/roles"
+
+	tele "gopkg.in/telebot.v4"
+)
 
 // AdminOptions defines how admin-only checks should behave.
 type AdminOptions struct {
-	AdminID  int64
+	AdminID int64
+	// AdminIDs lists additional admins accepted besides AdminID.
+	AdminIDs []int64
 	OnReject tele.HandlerFunc
 }
 
+func (o AdminOptions) enabled() bool {
+	return o.AdminID != 0 || len(o.AdminIDs) > 0
+}
+
+func (o AdminOptions) isAdmin(userID int64) bool {
+	if o.AdminID != 0 && userID == o.AdminID {
+		return true
+	}
+	for _, id := range o.AdminIDs {
+		if id != 0 && id == userID {
+			return true
+		}
+	}
+	return false
+}
+
 // WithAdminCheck wraps a command handler enforcing admin-only execution when required.
 func WithAdminCheck(opts AdminOptions, cmd struct {
 	AdminOnly bool
 	Handler   tele.HandlerFunc
 }) tele.HandlerFunc {
-	if !cmd.AdminOnly || opts.AdminID == 0 {
+	if !cmd.AdminOnly || !opts.enabled() {
 		return cmd.Handler
 	}
-	return func(c tele.Context) error {
-		if int64(c.Sender().ID) != opts.AdminID {
-			if opts.OnReject != nil {
-				return opts.OnReject(c)
-			}
-			return nil
-		}
-		return cmd.Handler(c)
-	}
+	return AdminOnlyMiddleware(opts)(cmd.Handler)
 }
 
 // AdminOnlyMiddleware ensures that only the admin user can invoke downstream handlers.
 func AdminOnlyMiddleware(opts AdminOptions) tele.MiddlewareFunc {
 	return func(next tele.HandlerFunc) tele.HandlerFunc {
 		return func(c tele.Context) error {
-			if opts.AdminID != 0 && int64(c.Sender().ID) != opts.AdminID {
+			if opts.enabled() && (c.Sender() == nil || !opts.isAdmin(c.Sender().ID)) {
 				if opts.OnReject != nil {
 					return opts.OnReject(c)
 				}
@@ -41,3 +60,73 @@ func AdminOnlyMiddleware(opts AdminOptions) tele.MiddlewareFunc {
 		}
 	}
 }
+
+// Guard combines AdminOnly and permission checks. With an authorizer both
+// are checked as permissions (AdminOnly requires roles.PermissionAll);
+// without one, AdminOnly compares the sender with the admin IDs. It returns
+// nil when neither check applies.
+func Guard(adminOnly bool, perms []roles.Permission, auth *roles.Authorizer, admin AdminOptions) tele.MiddlewareFunc {
+	if auth == nil {
+		if adminOnly {
+			return AdminOnlyMiddleware(admin)
+		}
+		if len(perms) == 0 {
+			return nil
+		}
+	}
+	if adminOnly {
+		perms = append([]roles.Permission{roles.PermissionAll}, perms...)
+	}
+	if len(perms) == 0 {
+		return nil
+	}
+	return RequirePermissions(PermissionOptions{
+		Authorizer:  auth,
+		Permissions: perms,
+		OnReject:    admin.OnReject,
+	})
+}
+
+// PermissionOptions configures RequirePermissions.
+type PermissionOptions struct {
+	Authorizer  *roles.Authorizer
+	Permissions []roles.Permission
+	OnReject    tele.HandlerFunc
+}
+
+// RequirePermissions lets the update through only when the sender holds every
+// configured permission. Resolver failures are treated as a denial.
+func RequirePermissions(opts PermissionOptions) tele.MiddlewareFunc {
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		if len(opts.Permissions) == 0 {
+			return next
+		}
+		return func(c tele.Context) error {
+			ok, err := opts.Authorizer.Allowed(c, opts.Permissions...)
+			if ok {
+				return next(c)
+			}
+			attrs := []slog.Attr{
+				slog.String("status", "skip"),
+				slog.String("reason", "forbidden"),
+				slog.String("permissions", joinPermissions(opts.Permissions)),
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("err", err.Error()))
+			}
+			logger.Warn(tghelpers.BuildContext(c), "tg", "access.denied", attrs...)
+			if opts.OnReject != nil {
+				return opts.OnReject(c)
+			}
+			return nil
+		}
+	}
+}
+
+func joinPermissions(perms []roles.Permission) string {
+	parts := make([]string, len(perms))
+	for i, p := range perms {
+		parts[i] = string(p)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,14 @@
+package middleware
+
+import tele "gopkg.in/telebot.v4"
+
+// Chain wraps h with mws so that they run in order, the first one outermost.
+// Nil entries are skipped.
+func Chain(h tele.HandlerFunc, mws ...tele.MiddlewareFunc) tele.HandlerFunc {
+	for i := len(mws) - 1; i >= 0; i-- {
+		if mws[i] != nil {
+			h = mws[i](h)
+		}
+	}
+	return h
+}
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/metrics"
 	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+	"github.com/m3rciful/gobot/core/tracing"
 	"log/slog"
 
 	tele "gopkg.in/telebot.v4"
@@ -36,7 +38,8 @@ func alreadyLogged(updateID int) bool {
 	return false
 }
 
-// LoggerMiddleware logs a single receipt line per update and sets rid.
+// LoggerMiddleware logs a single receipt line per update, sets rid and
+// starts the update span that handler and send spans hang off.
 // It deduplicates by update_id to prevent double logging when middleware is applied on multiple branches.
 func LoggerMiddleware(next tele.HandlerFunc) tele.HandlerFunc {
 	return func(c tele.Context) error {
@@ -59,6 +62,18 @@ func LoggerMiddleware(next tele.HandlerFunc) tele.HandlerFunc {
 		ctx := logger.WithRID(logger.Background(), rid)
 		ctx = logger.WithUpdateMeta(ctx, upd.ID, userID, chatID)
 		ctx = logger.WithLogger(ctx, logger.Component("tg"))
+
+		// Reuse the update span when an outer branch already started one.
+		var span *tracing.Span
+		if prev, ok := tghelpers.ContextFrom(c); ok && tracing.SpanFrom(prev) != nil {
+			ctx = tracing.ContextWithSpan(ctx, tracing.SpanFrom(prev))
+		} else {
+			ctx, span = tracing.Start(ctx, "update."+metrics.UpdateKind(upd), tracing.KindServer,
+				tracing.Int64("update_id", int64(upd.ID)),
+				tracing.Int64("chat_id", chatID),
+				tracing.Int64("user_id", userID),
+			)
+		}
 		tghelpers.StoreContext(c, ctx)
 
 		// Deduplicate update receipt logs
@@ -100,7 +115,9 @@ func LoggerMiddleware(next tele.HandlerFunc) tele.HandlerFunc {
 			logger.LogEvent(ctx, logger.Component("tg"), slog.LevelDebug, "update.received", attrs...)
 		}
 
-		return next(c)
+		err := next(c)
+		span.End(err)
+		return err
 	}
 }
 
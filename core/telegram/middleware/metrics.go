@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/metrics"
 	"log/slog"
 
 	tele "gopkg.in/telebot.v4"
@@ -86,6 +87,15 @@ func (m metricsContext) EditOrReply(what interface{}, opts ...interface{}) error
 	return err
 }
 
+// UpdateMetricsMiddleware counts every update in metrics.Updates; register it
+// before rate limiting so dropped updates are counted too.
+func UpdateMetricsMiddleware(next tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		metrics.Updates.With(metrics.UpdateKind(c.Update())).Inc()
+		return next(c)
+	}
+}
+
 // MessageMetricsMiddleware instruments context to track messages count and keyboard usage.
 func MessageMetricsMiddleware(next tele.HandlerFunc) tele.HandlerFunc {
 	return func(c tele.Context) error {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/metrics"
 	"log/slog"
 
 	tele "gopkg.in/telebot.v4"
@@ -51,6 +52,7 @@ func RateLimitMiddleware(opts RateLimitOptions) tele.MiddlewareFunc {
 			userLastSeenMu.Lock()
 			if last, ok := userLastSeen[user.ID]; ok && now.Sub(last) < opts.Interval {
 				userLastSeenMu.Unlock()
+				metrics.RateLimited.With(kind).Inc()
 				chat := c.Chat()
 				if chat != nil {
 					logger.TG.Warn("rate limit",
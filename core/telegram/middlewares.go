@@ -5,15 +5,35 @@ import (
 	"time"
 
 	coreconfig "github.com/m3rciful/gobot/core/config"
+	"github.com/m3rciful/gobot/core/telegram/access"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
 	"github.com/m3rciful/gobot/core/telegram/middleware"
 
 	tele "gopkg.in/telebot.v4"
 )
 
+// MiddlewareOptions customises DefaultMiddlewaresWithOptions.
+type MiddlewareOptions struct {
+	// OnLimited replies to rate-limited updates; nil keeps the default reply.
+	OnLimited func(tele.Context) error
+	// I18n configures locale resolution, for example a PreferenceStore with
+	// the locale each user picked.
+	I18n i18n.Options
+	// Access, when set, rejects updates from denied, banned or (in private
+	// mode) unknown users right after the locale is resolved.
+	Access *access.Guard
+}
+
 // DefaultMiddlewares builds the shared middleware chain for bots.
 func DefaultMiddlewares(cfg *coreconfig.Config, onLimited func(tele.Context) error) []Middleware {
+	return DefaultMiddlewaresWithOptions(cfg, MiddlewareOptions{OnLimited: onLimited})
+}
+
+// DefaultMiddlewaresWithOptions builds the shared middleware chain for bots.
+func DefaultMiddlewaresWithOptions(cfg *coreconfig.Config, opts MiddlewareOptions) []Middleware {
 	mws := []Middleware{
 		{Name: "recover", Use: middleware.RecoverMiddleware},
+		{Name: "updates", Use: middleware.UpdateMetricsMiddleware},
 	}
 
 	if cfg != nil {
@@ -23,16 +43,16 @@ func DefaultMiddlewares(cfg *coreconfig.Config, onLimited func(tele.Context) err
 			for _, t := range cfg.RateLimit.ExcludeUpdates {
 				ex[strings.ToLower(t)] = struct{}{}
 			}
-			opts := middleware.RateLimitOptions{
+			rlOpts := middleware.RateLimitOptions{
 				Interval: interval,
 				Exclude:  ex,
 			}
-			if onLimited != nil {
-				opts.OnLimited = onLimited
+			if opts.OnLimited != nil {
+				rlOpts.OnLimited = opts.OnLimited
 			}
 			mws = append(mws, Middleware{
 				Name: "rate_limit",
-				Use:  middleware.RateLimitMiddleware(opts),
+				Use:  middleware.RateLimitMiddleware(rlOpts),
 			})
 		}
 	}
@@ -40,7 +60,11 @@ func DefaultMiddlewares(cfg *coreconfig.Config, onLimited func(tele.Context) err
 	mws = append(mws,
 		Middleware{Name: "logger", Use: middleware.LoggerMiddleware},
 		Middleware{Name: "metrics", Use: middleware.MessageMetricsMiddleware},
+		Middleware{Name: "i18n", Use: i18n.Middleware(opts.I18n)},
 	)
+	if opts.Access != nil {
+		mws = append(mws, Middleware{Name: "access", Use: opts.Access.Middleware()})
+	}
 
 	return mws
 }
@@ -0,0 +1,59 @@
+package telegram
+
+import (
+	"context"
+	"time"
+
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// SetPayloadStore enables server-side storage for callback payloads that do
+// not fit into Telegram's 64-byte callback_data.
+func (r *Registry) SetPayloadStore(store callbacks.PayloadStore) {
+	r.callbacksMu.Lock()
+	defer r.callbacksMu.Unlock()
+	r.payloads = store
+}
+
+// PayloadStore returns the store set with SetPayloadStore, if any.
+func (r *Registry) PayloadStore() callbacks.PayloadStore {
+	if r == nil {
+		return nil
+	}
+	r.callbacksMu.RLock()
+	defer r.callbacksMu.RUnlock()
+	return r.payloads
+}
+
+// DataButton builds an inline button for key carrying payload. Payloads that
+// do not fit into callback_data are saved in the payload store for ttl and
+// replaced by a short token that CallbackRoute resolves before the handler runs.
+func (r *Registry) DataButton(ctx context.Context, markup *tele.ReplyMarkup, text, key, payload string, ttl time.Duration) (tele.Btn, error) {
+	payload, err := r.fitPayload(ctx, key, payload, ttl)
+	if err != nil {
+		return tele.Btn{}, err
+	}
+	return markup.Data(text, key, payload), nil
+}
+
+// fitPayload returns payload or, when it is too long for callback_data, a stored token.
+func (r *Registry) fitPayload(ctx context.Context, key, payload string, ttl time.Duration) (string, error) {
+	// Telebot sends "\f<key>|<payload>".
+	if len(key)+len(payload)+2 <= callbacks.MaxDataLen && !callbacks.IsToken(payload) {
+		return payload, nil
+	}
+	store := r.PayloadStore()
+	if store == nil {
+		return "", callbacks.ErrTooLong
+	}
+	token, err := store.Put(ctx, key, payload, ttl)
+	if err != nil {
+		return "", err
+	}
+	if len(key)+len(token)+2 > callbacks.MaxDataLen {
+		return "", callbacks.ErrTooLong
+	}
+	return token, nil
+}
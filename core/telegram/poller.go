@@ -1,10 +1,13 @@
 package telegram
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
 	"strings"
 	"time"
 
+	"github.com/m3rciful/gobot/core/logger"
+
 	tele "gopkg.in/telebot.v4"
 )
 
@@ -18,6 +21,29 @@ type WebhookOptions struct {
 	Listen string
 	Port   int
 	URL    string
+	// Path is the local path updates are served on; defaults to the path of URL.
+	Path string
+	// SecretToken is registered with Telegram and required on every request.
+	SecretToken string
+	// CertFile and KeyFile enable a TLS listener; SelfSigned also uploads
+	// CertFile to Telegram so it trusts the certificate.
+	CertFile   string
+	KeyFile    string
+	SelfSigned bool
+
+	AllowedUpdates     []string
+	MaxConnections     int
+	DropPendingUpdates bool
+
+	// RestrictIPs accepts requests only from AllowedNetworks (CIDRs,
+	// defaulting to TelegramNetworks).
+	RestrictIPs     bool
+	AllowedNetworks []string
+	// TrustProxy takes the client address from X-Forwarded-For.
+	TrustProxy bool
+	// External skips the built-in listener; mount the Webhook handler
+	// (Runtime.Webhook) on your own server at Webhook.Path.
+	External bool
 }
 
 // PollerOptions configures BuildPoller.
@@ -27,19 +53,33 @@ type PollerOptions struct {
 	Webhook                WebhookOptions
 }
 
-// BuildPoller returns a Telebot poller based on provided options.
-func BuildPoller(opts PollerOptions) tele.Poller {
+// NewPoller returns a *Webhook or a *tele.LongPoller based on provided options.
+func NewPoller(opts PollerOptions) (tele.Poller, error) {
 	runMode := strings.ToLower(strings.TrimSpace(opts.RunMode))
 	if runMode == RunModeWebhook {
-		return &tele.Webhook{
-			Listen:   fmt.Sprintf("%s:%d", opts.Webhook.Listen, opts.Webhook.Port),
-			Endpoint: &tele.WebhookEndpoint{PublicURL: opts.Webhook.URL},
-		}
+		return NewWebhook(opts.Webhook)
 	}
 
 	timeoutSec := opts.LongPollTimeoutSeconds
 	if timeoutSec <= 0 {
 		timeoutSec = 10
 	}
-	return &tele.LongPoller{Timeout: time.Duration(timeoutSec) * time.Second}
+	return &tele.LongPoller{Timeout: time.Duration(timeoutSec) * time.Second}, nil
+}
+
+// BuildPoller returns a Telebot poller based on provided options. Invalid
+// webhook options are logged and a long poller is returned instead.
+//
+// Deprecated: use NewPoller, which reports invalid webhook options.
+func BuildPoller(opts PollerOptions) tele.Poller {
+	poller, err := NewPoller(opts)
+	if err != nil {
+		logger.Error(context.Background(), "tg", "poller.invalid",
+			slog.String("err", err.Error()),
+			slog.String("fallback", RunModeLongpoll),
+		)
+		opts.RunMode = RunModeLongpoll
+		poller, _ = NewPoller(opts)
+	}
+	return poller
 }
@@ -9,7 +9,11 @@ import (
 	"sync"
 
 	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
 	"github.com/m3rciful/gobot/core/telegram/commands"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
+	"github.com/m3rciful/gobot/core/telegram/middleware"
+	"github.com/m3rciful/gobot/core/telegram/roles"
 	"log/slog"
 
 	tele "gopkg.in/telebot.v4"
@@ -19,18 +23,35 @@ import (
 type Registry struct {
 	commands         map[string]commands.Command
 	callbacks        map[string]tele.HandlerFunc
+	callbackPerms    map[string][]roles.Permission
+	typedVersions    map[string]uint
+	codec            *callbacks.Codec
+	payloads         callbacks.PayloadStore
 	callbacksMu      sync.RWMutex
 	callbackNotFound tele.HandlerFunc
 	textFallback     tele.HandlerFunc
+	textRoutes       []TextRoute
+	textMu           sync.RWMutex
+	media            map[MediaKind]tele.HandlerFunc
+	mediaMu          sync.RWMutex
+	inline           map[string]InlineRoute
+	inlineMu         sync.RWMutex
+	authorizer       *roles.Authorizer
+	adminOpts        middleware.AdminOptions
+	middleware       []tele.MiddlewareFunc
+	menus            map[publishedMenu]struct{}
+	menusMu          sync.Mutex
 }
 
 // NewRegistry creates an empty Registry with default fallbacks.
 func NewRegistry() *Registry {
 	return &Registry{
-		commands:  make(map[string]commands.Command),
-		callbacks: make(map[string]tele.HandlerFunc),
+		commands:      make(map[string]commands.Command),
+		callbacks:     make(map[string]tele.HandlerFunc),
+		callbackPerms: make(map[string][]roles.Permission),
+		typedVersions: make(map[string]uint),
 		callbackNotFound: func(c tele.Context) error {
-			_ = c.Respond(&tele.CallbackResponse{Text: "Unsupported action"})
+			_ = c.Respond(&tele.CallbackResponse{Text: i18n.T(c, i18n.KeyUnsupportedAction)})
 			return nil
 		},
 	}
@@ -38,27 +59,40 @@ func NewRegistry() *Registry {
 
 // RegisterCommand adds a new command.
 func (r *Registry) RegisterCommand(name string, cmd commands.Command) {
-	if r == nil || name == "" || cmd.Handler == nil || cmd.Description == "" {
+	r.registerCommand(name, cmd)
+}
+
+func (r *Registry) registerCommand(name string, cmd commands.Command) bool {
+	runnable := cmd.Handler != nil || cmd.Run != nil || len(cmd.Subcommands) > 0
+	if r == nil || name == "" || !runnable || cmd.Description == "" {
 		logger.TWire.LogAttrs(context.Background(), slog.LevelWarn, "register.command.skip",
 			slog.String("name", name),
 			slog.String("reason", "invalid"),
 		)
-		return
+		return false
 	}
 	if name[0] != '/' {
 		logger.TWire.LogAttrs(context.Background(), slog.LevelWarn, "register.command.skip",
 			slog.String("name", name),
 			slog.String("reason", "no_slash_prefix"),
 		)
-		return
+		return false
 	}
 	if _, exists := r.commands[name]; exists {
 		logger.TWire.LogAttrs(context.Background(), slog.LevelWarn, "register.command.duplicate",
 			slog.String("name", name),
 		)
-		return
+		return false
+	}
+	switch {
+	case len(cmd.Subcommands) > 0:
+		cmd.Subcommands = normalizeSubcommands(cmd.Subcommands)
+		cmd.Handler = r.subcommandRouter(name, cmd)
+	case cmd.Handler == nil:
+		cmd.Handler = commands.Bind(name, cmd)
 	}
 	r.commands[name] = cmd
+	return true
 }
 
 // ListCommands returns a slice of tele.Command, optionally filtering out hidden and admin-only commands.
@@ -97,8 +131,9 @@ func (r *Registry) Commands() map[string]commands.Command {
 	return r.commands
 }
 
-// RegisterCallback adds a callback handler mapped to its key.
-func (r *Registry) RegisterCallback(key string, handler tele.HandlerFunc) error {
+// RegisterCallback adds a callback handler mapped to its key. Middleware wraps
+// the handler and runs after the registry middleware (see Use).
+func (r *Registry) RegisterCallback(key string, handler tele.HandlerFunc, mws ...tele.MiddlewareFunc) error {
 	if r == nil || key == "" || handler == nil {
 		logger.TWire.LogAttrs(context.Background(), slog.LevelWarn, "register.callback.skip",
 			slog.String("key", key),
@@ -114,10 +149,31 @@ func (r *Registry) RegisterCallback(key string, handler tele.HandlerFunc) error
 		)
 		return fmt.Errorf("callback already registered: %s", key)
 	}
-	r.callbacks[key] = handler
+	r.callbacks[key] = middleware.Chain(handler, mws...)
 	return nil
 }
 
+// RegisterCallbackWithPermissions adds a callback handler that only runs when
+// the sender holds every permission in perms (checked by CallbackRoute).
+func (r *Registry) RegisterCallbackWithPermissions(key string, handler tele.HandlerFunc, perms ...roles.Permission) error {
+	if err := r.RegisterCallback(key, handler); err != nil {
+		return err
+	}
+	if len(perms) > 0 {
+		r.callbacksMu.Lock()
+		r.callbackPerms[key] = append([]roles.Permission(nil), perms...)
+		r.callbacksMu.Unlock()
+	}
+	return nil
+}
+
+// CallbackPermissions returns the permissions required by a callback key.
+func (r *Registry) CallbackPermissions(key string) []roles.Permission {
+	r.callbacksMu.RLock()
+	defer r.callbacksMu.RUnlock()
+	return r.callbackPerms[key]
+}
+
 // GetCallback safely returns handler by key.
 func (r *Registry) GetCallback(key string) (tele.HandlerFunc, bool) {
 	r.callbacksMu.RLock()
@@ -150,6 +206,64 @@ func (r *Registry) CallbackNotFound() tele.HandlerFunc {
 	return r.callbackNotFound
 }
 
+// SetAuthorizer sets the role-based authorizer shared by routers, sub-commands and help.
+func (r *Registry) SetAuthorizer(a *roles.Authorizer) {
+	r.authorizer = a
+}
+
+// Authorizer returns the authorizer set via SetAuthorizer, if any.
+func (r *Registry) Authorizer() *roles.Authorizer {
+	if r == nil {
+		return nil
+	}
+	return r.authorizer
+}
+
+// SetAdminOptions sets the admins accepted for AdminOnly sub-commands when no
+// authorizer is set, and the handler run when a sub-command is rejected.
+// CommandRoutes sets them from its own options.
+func (r *Registry) SetAdminOptions(opts middleware.AdminOptions) {
+	r.adminOpts = opts
+}
+
+// Guard returns middleware enforcing adminOnly and perms like CommandRoutes
+// does, or nil when both are empty. The authorizer and admin options are read
+// per update, so they may be configured after commands are registered.
+func (r *Registry) Guard(adminOnly bool, perms []roles.Permission) tele.MiddlewareFunc {
+	if !adminOnly && len(perms) == 0 {
+		return nil
+	}
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			guard := middleware.Guard(adminOnly, perms, r.Authorizer(), r.adminOpts)
+			if guard == nil {
+				return next(c)
+			}
+			return guard(next)(c)
+		}
+	}
+}
+
+// Use appends middleware applied by the routers to every registered command
+// and callback, after the global chain and access checks.
+func (r *Registry) Use(mws ...tele.MiddlewareFunc) {
+	r.middleware = append(r.middleware, mws...)
+}
+
+// Middleware returns the registry-level middleware added with Use.
+func (r *Registry) Middleware() []tele.MiddlewareFunc {
+	if r == nil {
+		return nil
+	}
+	return r.middleware
+}
+
+// CommandHandler returns cmd.Handler wrapped with the registry middleware
+// followed by cmd.Middleware.
+func (r *Registry) CommandHandler(cmd commands.Command) tele.HandlerFunc {
+	return middleware.Chain(middleware.Chain(cmd.Handler, cmd.Middleware...), r.Middleware()...)
+}
+
 // SetTextFallback sets a global fallback handler for unknown text messages.
 func (r *Registry) SetTextFallback(h tele.HandlerFunc) {
 	r.textFallback = h
@@ -159,13 +273,3 @@ func (r *Registry) SetTextFallback(h tele.HandlerFunc) {
 func (r *Registry) TextFallback() tele.HandlerFunc {
 	return r.textFallback
 }
-
-// InitBotCommands sets the Telegram bot commands shown in the command menu.
-func InitBotCommands(bot *tele.Bot, reg *Registry) {
-	commands := reg.ListCommands(true)
-	if err := bot.SetCommands(commands); err != nil {
-		logger.TWire.LogAttrs(context.Background(), slog.LevelError, "register.commands.set_failed",
-			slog.String("err", err.Error()),
-		)
-	}
-}
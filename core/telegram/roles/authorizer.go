@@ -0,0 +1,96 @@
+package roles
+
+import (
+	coreconfig "github.com/m3rciful/gobot/core/config"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const rolesKey = "roles"
+
+// Authorizer checks permissions of the update sender using a Resolver and a Policy.
+type Authorizer struct {
+	Resolver Resolver
+	Policy   *Policy
+}
+
+// NewFromConfig builds an Authorizer from the core config: admin IDs map to
+// RoleAdmin, roles.users assigns custom roles and roles.permissions grants
+// permissions. Extra resolvers (database, chat admins) are chained after it.
+func NewFromConfig(cfg *coreconfig.Config, extra ...Resolver) *Authorizer {
+	static := StaticResolver{}
+	policy := NewPolicy()
+	if cfg != nil {
+		static.Add(RoleAdmin, cfg.Telegram.AdminID)
+		static.Add(RoleAdmin, cfg.Telegram.AdminIDs...)
+		for role, ids := range cfg.Roles.Users {
+			static.Add(Role(role), ids...)
+		}
+		for role, perms := range cfg.Roles.Permissions {
+			for _, p := range perms {
+				policy.Grant(Role(role), Permission(p))
+			}
+		}
+	}
+	return &Authorizer{
+		Resolver: Chain(append([]Resolver{static}, extra...)...),
+		Policy:   policy,
+	}
+}
+
+// RolesOf resolves the sender roles once per update and caches them on the context.
+func (a *Authorizer) RolesOf(c tele.Context) ([]Role, error) {
+	if cached, ok := c.Get(rolesKey).([]Role); ok {
+		return cached, nil
+	}
+	if a == nil || a.Resolver == nil {
+		return nil, nil
+	}
+	got, err := a.Resolver.Roles(c)
+	if err != nil {
+		return nil, err
+	}
+	if got == nil {
+		got = []Role{}
+	}
+	c.Set(rolesKey, got)
+	return got, nil
+}
+
+// Allowed reports whether the sender holds every permission in perms.
+func (a *Authorizer) Allowed(c tele.Context, perms ...Permission) (bool, error) {
+	if len(perms) == 0 {
+		return true, nil
+	}
+	got, err := a.RolesOf(c)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range perms {
+		if !a.policy().Allows(got, p) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// HasRole reports whether the sender holds role.
+func (a *Authorizer) HasRole(c tele.Context, role Role) (bool, error) {
+	got, err := a.RolesOf(c)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range got {
+		if r == role {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (a *Authorizer) policy() *Policy {
+	if a == nil || a.Policy == nil {
+		return NewPolicy()
+	}
+	return a.Policy
+}
@@ -0,0 +1,2 @@
+// Package roles implements role-based access control for bot commands and callbacks.
+package roles
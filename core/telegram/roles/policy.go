@@ -0,0 +1,73 @@
+package roles
+
+import "sync"
+
+// Role names a group of users sharing the same permissions.
+type Role string
+
+// Permission names a single guarded capability, e.g. "users.ban".
+type Permission string
+
+// Built-in roles. Bots are free to define their own.
+const (
+	// RoleAdmin is granted PermissionAll by NewPolicy.
+	RoleAdmin Role = "admin"
+	// RoleModerator is a conventional role without default permissions.
+	RoleModerator Role = "moderator"
+	// RoleChatAdmin is assigned by ChatAdminResolver to group administrators.
+	RoleChatAdmin Role = "chat_admin"
+)
+
+// PermissionAll matches every permission. Commands marked AdminOnly require it.
+const PermissionAll Permission = "*"
+
+// Policy maps roles to the permissions they grant.
+type Policy struct {
+	mu     sync.RWMutex
+	grants map[Role]map[Permission]struct{}
+}
+
+// NewPolicy creates a policy where RoleAdmin holds PermissionAll.
+func NewPolicy() *Policy {
+	p := &Policy{grants: make(map[Role]map[Permission]struct{})}
+	p.Grant(RoleAdmin, PermissionAll)
+	return p
+}
+
+// Grant adds permissions to role.
+func (p *Policy) Grant(role Role, perms ...Permission) {
+	if p == nil || role == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	set, ok := p.grants[role]
+	if !ok {
+		set = make(map[Permission]struct{}, len(perms))
+		p.grants[role] = set
+	}
+	for _, perm := range perms {
+		if perm != "" {
+			set[perm] = struct{}{}
+		}
+	}
+}
+
+// Allows reports whether any of roles grants perm.
+func (p *Policy) Allows(roles []Role, perm Permission) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, r := range roles {
+		set := p.grants[r]
+		if _, ok := set[PermissionAll]; ok {
+			return true
+		}
+		if _, ok := set[perm]; ok {
+			return true
+		}
+	}
+	return false
+}
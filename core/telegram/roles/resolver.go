@@ -0,0 +1,184 @@
+package roles
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/m3rciful/gobot/core/logger"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Resolver returns the roles held by the sender of an update.
+type Resolver interface {
+	Roles(c tele.Context) ([]Role, error)
+}
+
+// ResolverFunc adapts a bare function to the Resolver interface.
+type ResolverFunc func(c tele.Context) ([]Role, error)
+
+// Roles executes the underlying function.
+func (f ResolverFunc) Roles(c tele.Context) ([]Role, error) {
+	return f(c)
+}
+
+// StaticResolver assigns roles from a fixed user ID mapping, typically loaded from config.
+type StaticResolver map[int64][]Role
+
+// Roles returns the roles configured for the sender.
+func (s StaticResolver) Roles(c tele.Context) ([]Role, error) {
+	user := c.Sender()
+	if user == nil {
+		return nil, nil
+	}
+	return s[user.ID], nil
+}
+
+// Add assigns role to every user in ids.
+func (s StaticResolver) Add(role Role, ids ...int64) {
+	for _, id := range ids {
+		if id != 0 {
+			s[id] = append(s[id], role)
+		}
+	}
+}
+
+const defaultRolesQuery = `SELECT role FROM user_roles WHERE user_id = $1`
+
+// SQLResolver loads roles from a database table keyed by Telegram user ID.
+type SQLResolver struct {
+	DB *sqlx.DB
+	// Query must select role names for the user ID bound to $1.
+	// Defaults to SELECT role FROM user_roles WHERE user_id = $1.
+	Query string
+}
+
+// Roles queries the roles of the sender.
+func (r SQLResolver) Roles(c tele.Context) ([]Role, error) {
+	user := c.Sender()
+	if user == nil || r.DB == nil {
+		return nil, nil
+	}
+	query := r.Query
+	if query == "" {
+		query = defaultRolesQuery
+	}
+	var names []string
+	if err := r.DB.SelectContext(tghelpers.BuildContext(c), &names, query, user.ID); err != nil {
+		return nil, fmt.Errorf("roles: query user roles: %w", err)
+	}
+	out := make([]Role, 0, len(names))
+	for _, n := range names {
+		out = append(out, Role(n))
+	}
+	return out, nil
+}
+
+// ChatAdminResolver grants RoleChatAdmin to creators and administrators of
+// the current group chat, looked up via getChatMember and cached for TTL.
+// Expired entries are swept at most once per TTL.
+type ChatAdminResolver struct {
+	TTL time.Duration
+
+	mu    sync.Mutex
+	cache map[[2]int64]chatAdminEntry
+	swept time.Time
+}
+
+type chatAdminEntry struct {
+	admin   bool
+	expires time.Time
+}
+
+// NewChatAdminResolver creates a resolver caching lookups for ttl (default one minute).
+func NewChatAdminResolver(ttl time.Duration) *ChatAdminResolver {
+	if ttl <= 0 {
+		ttl = time.Minute
+	}
+	return &ChatAdminResolver{TTL: ttl, cache: make(map[[2]int64]chatAdminEntry)}
+}
+
+// Roles returns RoleChatAdmin when the sender administers the current group.
+func (r *ChatAdminResolver) Roles(c tele.Context) ([]Role, error) {
+	chat, user := c.Chat(), c.Sender()
+	if chat == nil || user == nil {
+		return nil, nil
+	}
+	if chat.Type != tele.ChatGroup && chat.Type != tele.ChatSuperGroup {
+		return nil, nil
+	}
+
+	key := [2]int64{chat.ID, user.ID}
+	now := time.Now()
+	r.mu.Lock()
+	entry, ok := r.cache[key]
+	r.mu.Unlock()
+	if !ok || now.After(entry.expires) {
+		member, err := c.Bot().ChatMemberOf(chat, user)
+		if err != nil {
+			return nil, fmt.Errorf("roles: get chat member: %w", err)
+		}
+		entry = chatAdminEntry{
+			admin:   member.Role == tele.Creator || member.Role == tele.Administrator,
+			expires: now.Add(r.TTL),
+		}
+		r.mu.Lock()
+		if r.cache == nil {
+			r.cache = make(map[[2]int64]chatAdminEntry)
+		}
+		r.sweep(now)
+		r.cache[key] = entry
+		r.mu.Unlock()
+	}
+	if entry.admin {
+		return []Role{RoleChatAdmin}, nil
+	}
+	return nil, nil
+}
+
+// sweep drops expired entries; r.mu must be held.
+func (r *ChatAdminResolver) sweep(now time.Time) {
+	if now.Sub(r.swept) < r.TTL {
+		return
+	}
+	r.swept = now
+	for key, entry := range r.cache {
+		if now.After(entry.expires) {
+			delete(r.cache, key)
+		}
+	}
+}
+
+// Chain merges the roles returned by several resolvers. A failing resolver
+// is logged and skipped so one unavailable source does not lock users out.
+func Chain(resolvers ...Resolver) Resolver {
+	return ResolverFunc(func(c tele.Context) ([]Role, error) {
+		var out []Role
+		seen := make(map[Role]struct{})
+		for _, r := range resolvers {
+			if r == nil {
+				continue
+			}
+			got, err := r.Roles(c)
+			if err != nil {
+				logger.Warn(tghelpers.BuildContext(c), "tg", "roles.resolve.fail",
+					slog.String("err", err.Error()),
+				)
+				continue
+			}
+			for _, role := range got {
+				if _, dup := seen[role]; dup {
+					continue
+				}
+				seen[role] = struct{}{}
+				out = append(out, role)
+			}
+		}
+		return out, nil
+	})
+}
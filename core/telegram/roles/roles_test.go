@@ -0,0 +1,106 @@
+package roles
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	coreconfig "github.com/m3rciful/gobot/core/config"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func newContext(t *testing.T, userID int64) tele.Context {
+	t.Helper()
+	bot, err := tele.NewBot(tele.Settings{Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bot.NewContext(tele.Update{Message: &tele.Message{
+		Sender: &tele.User{ID: userID},
+		Chat:   &tele.Chat{ID: userID, Type: tele.ChatPrivate},
+	}})
+}
+
+func TestPolicyAllows(t *testing.T) {
+	p := NewPolicy()
+	p.Grant(RoleModerator, "users.ban", "")
+
+	cases := []struct {
+		roles []Role
+		perm  Permission
+		want  bool
+	}{
+		{[]Role{RoleAdmin}, "anything", true},
+		{[]Role{RoleModerator}, "users.ban", true},
+		{[]Role{RoleModerator}, "users.delete", false},
+		{[]Role{RoleModerator}, "", false},
+		{nil, "users.ban", false},
+	}
+	for _, tc := range cases {
+		if got := p.Allows(tc.roles, tc.perm); got != tc.want {
+			t.Errorf("Allows(%v, %q) = %v, want %v", tc.roles, tc.perm, got, tc.want)
+		}
+	}
+	if (*Policy)(nil).Allows([]Role{RoleAdmin}, "x") {
+		t.Fatal("nil policy must deny")
+	}
+}
+
+func TestChainMergesAndSkipsFailures(t *testing.T) {
+	failing := ResolverFunc(func(tele.Context) ([]Role, error) { return nil, errors.New("db down") })
+	static := StaticResolver{}
+	static.Add(RoleModerator, 7)
+	static.Add(RoleAdmin, 7)
+	dup := StaticResolver{7: {RoleModerator}}
+
+	got, err := Chain(failing, static, nil, dup).Roles(newContext(t, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != RoleModerator || got[1] != RoleAdmin {
+		t.Fatalf("roles = %v", got)
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	cfg := &coreconfig.Config{}
+	cfg.Telegram.AdminID = 1
+	cfg.Roles.Users = map[string][]int64{"support": {2}}
+	cfg.Roles.Permissions = map[string][]string{"support": {"tickets.read"}}
+	auth := NewFromConfig(cfg)
+
+	cases := []struct {
+		user int64
+		perm Permission
+		want bool
+	}{
+		{1, PermissionAll, true},
+		{2, "tickets.read", true},
+		{2, PermissionAll, false},
+		{3, "tickets.read", false},
+	}
+	for _, tc := range cases {
+		got, err := auth.Allowed(newContext(t, tc.user), tc.perm)
+		if err != nil || got != tc.want {
+			t.Errorf("user %d %q: got %v, %v; want %v", tc.user, tc.perm, got, err, tc.want)
+		}
+	}
+}
+
+func TestChatAdminResolverSweep(t *testing.T) {
+	r := NewChatAdminResolver(time.Minute)
+	now := time.Now()
+	r.cache[[2]int64{1, 1}] = chatAdminEntry{expires: now.Add(-time.Second)}
+	r.cache[[2]int64{1, 2}] = chatAdminEntry{expires: now.Add(time.Second)}
+
+	r.sweep(now)
+	if len(r.cache) != 1 {
+		t.Fatalf("cache size after sweep = %d, want 1", len(r.cache))
+	}
+	r.cache[[2]int64{1, 3}] = chatAdminEntry{expires: now.Add(-time.Second)}
+	r.sweep(now.Add(time.Second))
+	if len(r.cache) != 2 {
+		t.Fatal("sweep ran again before TTL elapsed")
+	}
+}
@@ -3,8 +3,12 @@ package router
 import (
 	"time"
 
+	"github.com/m3rciful/gobot/core/logger"
 	tg "github.com/m3rciful/gobot/core/telegram"
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
 	"github.com/m3rciful/gobot/core/telegram/middleware"
+	"github.com/m3rciful/gobot/core/telegram/roles"
 	"log/slog"
 
 	tele "gopkg.in/telebot.v4"
@@ -13,10 +17,22 @@ import (
 // CallbackOptions customises fallback behaviour for callbacks.
 type CallbackOptions struct {
 	NotFound tele.HandlerFunc
+
+	// Authorizer checks permissions of callbacks registered with
+	// RegisterCallbackWithPermissions and defaults to the registry authorizer;
+	// without one such callbacks are denied.
+	Authorizer *roles.Authorizer
+	// OnForbidden runs when the sender lacks the required permissions.
+	OnForbidden tele.HandlerFunc
+	// OnExpired runs when a stored payload token is unknown or expired.
+	OnExpired tele.HandlerFunc
 }
 
 // CallbackRoute returns a handler that routes callbacks through the registry.
 func CallbackRoute(reg *tg.Registry, opts CallbackOptions) tg.Route {
+	if opts.Authorizer == nil {
+		opts.Authorizer = reg.Authorizer()
+	}
 	handler := func(c tele.Context) error {
 		start := time.Now()
 		if c.Callback() == nil {
@@ -24,12 +40,16 @@ func CallbackRoute(reg *tg.Registry, opts CallbackOptions) tg.Route {
 		}
 
 		key, _ := parseCallback(c.Callback())
-		name := "callback." + normalizeHandlerName(key)
-		extras := []slog.Attr{slog.String("cb_key", key)}
+		extras := []slog.Attr{slog.String("cb_key", logger.SanitizeLimit(key, 64))}
 
 		_ = c.Respond()
 
 		cbHandler, ok := reg.GetCallback(key)
+		// The key comes from the client; only registered keys become metric labels.
+		name := "callback.unknown"
+		if ok {
+			name = "callback." + normalizeHandlerName(key)
+		}
 		if !ok || cbHandler == nil {
 			fallback := reg.CallbackNotFound()
 			if fallback == nil {
@@ -44,8 +64,41 @@ func CallbackRoute(reg *tg.Registry, opts CallbackOptions) tg.Route {
 			}, extras...)
 		}
 
+		if payload := callbacks.CallbackPayload(c); callbacks.IsToken(payload) {
+			resolved, found, err := resolvePayload(c, reg, key, payload)
+			if !found {
+				extras = append(extras, slog.String("reason", "payload_expired"))
+				if err != nil {
+					extras = append(extras, slog.String("err", err.Error()))
+				}
+				return handleWithSummary(c, name, start, "skip", "", func() error {
+					if opts.OnExpired != nil {
+						return opts.OnExpired(c)
+					}
+					return nil
+				}, extras...)
+			}
+			callbacks.SetPayload(c.Callback(), resolved)
+		}
+
+		if perms := reg.CallbackPermissions(key); len(perms) > 0 {
+			allowed, err := opts.Authorizer.Allowed(c, perms...)
+			if !allowed {
+				extras = append(extras, slog.String("reason", "forbidden"))
+				if err != nil {
+					extras = append(extras, slog.String("err", err.Error()))
+				}
+				return handleWithSummary(c, name, start, "skip", "", func() error {
+					if opts.OnForbidden != nil {
+						return opts.OnForbidden(c)
+					}
+					return nil
+				}, extras...)
+			}
+		}
+
 		return handleWithSummary(c, name, start, "", "", func() error {
-			return cbHandler(c)
+			return middleware.Chain(cbHandler, reg.Middleware()...)(c)
 		}, extras...)
 	}
 	return tg.Route{
@@ -53,3 +106,12 @@ func CallbackRoute(reg *tg.Registry, opts CallbackOptions) tg.Route {
 		Handler:  middleware.RecoverMiddleware(middleware.LoggerMiddleware(handler)),
 	}
 }
+
+// resolvePayload loads the payload stored for key under token.
+func resolvePayload(c tele.Context, reg *tg.Registry, key, token string) (string, bool, error) {
+	store := reg.PayloadStore()
+	if store == nil {
+		return "", false, nil
+	}
+	return store.Get(tghelpers.BuildContext(c), key, token)
+}
@@ -0,0 +1,45 @@
+package router
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/m3rciful/gobot/core/metrics"
+	tg "github.com/m3rciful/gobot/core/telegram"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestCallbackRouteNamesUnknownKeys(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer api.Close()
+	bot, err := tele.NewBot(tele.Settings{URL: api.URL, Token: "test", Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := metrics.HandlerOutcomes.With("callback.unknown", "ok").Value()
+	route := CallbackRoute(tg.NewRegistry(), CallbackOptions{})
+	c := bot.NewContext(tele.Update{Callback: &tele.Callback{
+		ID:     "1",
+		Sender: &tele.User{ID: 1},
+		Data:   "forged_key_42|x",
+	}})
+	if err := route.Handler(c); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := metrics.HandlerOutcomes.With("callback.unknown", "ok").Value(); got != before+1 {
+		t.Fatalf("callback.unknown outcomes = %v, want %v", got, before+1)
+	}
+	var buf bytes.Buffer
+	metrics.Default.WriteText(&buf)
+	if strings.Contains(buf.String(), "forged_key_42") {
+		t.Fatal("client-supplied callback key exported as a metric label")
+	}
+}
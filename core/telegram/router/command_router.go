@@ -3,7 +3,9 @@ package router
 import (
 	"github.com/m3rciful/gobot/core/logger"
 	tg "github.com/m3rciful/gobot/core/telegram"
+	"github.com/m3rciful/gobot/core/telegram/commands"
 	"github.com/m3rciful/gobot/core/telegram/middleware"
+	"github.com/m3rciful/gobot/core/telegram/roles"
 	"log/slog"
 
 	tele "gopkg.in/telebot.v4"
@@ -11,29 +13,48 @@ import (
 
 // CommandRouteOptions configures how commands are wrapped and exposed.
 type CommandRouteOptions struct {
-	AdminID      int64
+	AdminID int64
+	// AdminIDs lists additional admins (telegram.admin_ids) accepted besides AdminID.
+	AdminIDs      []int64
 	OnAdminReject tele.HandlerFunc
+
+	// Authorizer enables role-based checks for AdminOnly and Permissions.
+	// Defaults to the registry authorizer; when both are nil, AdminOnly falls
+	// back to comparing the sender with AdminID and AdminIDs.
+	Authorizer *roles.Authorizer
 }
 
-// CommandRoutes prepares command handlers wrapped with shared middleware.
+// CommandRoutes prepares command handlers wrapped with shared middleware:
+// Recover → Logger → access guard → registry middleware → command middleware.
+// The admin fallback and authorizer are also installed on the registry so
+// sub-commands are guarded the same way.
 func CommandRoutes(reg *tg.Registry, opts CommandRouteOptions) []tg.Route {
 	if reg == nil {
 		return nil
 	}
+	if opts.Authorizer == nil {
+		opts.Authorizer = reg.Authorizer()
+	} else if reg.Authorizer() == nil {
+		reg.SetAuthorizer(opts.Authorizer)
+	}
 
 	adminOpts := middleware.AdminOptions{
 		AdminID:  opts.AdminID,
+		AdminIDs: opts.AdminIDs,
 		OnReject: opts.OnAdminReject,
 	}
+	reg.SetAdminOptions(adminOpts)
 
 	routes := make([]tg.Route, 0, len(reg.Commands()))
 	for cmd, def := range reg.Commands() {
-		h := def.Handler
-		h = middleware.RecoverMiddleware(h)
-		h = middleware.LoggerMiddleware(h)
-		if def.AdminOnly {
-			h = middleware.AdminOnlyMiddleware(adminOpts)(h)
+		// Wrapped inside out: the guard runs within Logger and Recover.
+		h := reg.CommandHandler(def)
+		if guard := middleware.Guard(def.AdminOnly, def.Permissions, opts.Authorizer, adminOpts); guard != nil {
+			h = guard(h)
 		}
+		h = withInvocation(h)
+		h = middleware.LoggerMiddleware(h)
+		h = middleware.RecoverMiddleware(h)
 		routes = append(routes, tg.Route{
 			Endpoint: cmd,
 			Handler:  h,
@@ -48,3 +69,11 @@ func CommandRoutes(reg *tg.Registry, opts CommandRouteOptions) []tg.Route {
 
 	return routes
 }
+
+// withInvocation normalises the payload of "/cmd@bot args" before next runs.
+func withInvocation(next tele.HandlerFunc) tele.HandlerFunc {
+	return func(c tele.Context) error {
+		commands.Invoke(c)
+		return next(c)
+	}
+}
@@ -0,0 +1,49 @@
+package router
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/m3rciful/gobot/core/logger"
+	tg "github.com/m3rciful/gobot/core/telegram"
+	"github.com/m3rciful/gobot/core/telegram/commands"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestCommandRoutesAcceptEveryAdmin(t *testing.T) {
+	if logger.TWire == nil {
+		logger.TWire = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	var ran, rejected int
+	reg := tg.NewRegistry()
+	reg.RegisterCommand("/ban", commands.Command{
+		Description: "Ban a user",
+		AdminOnly:   true,
+		Handler: func(tele.Context) error {
+			ran++
+			return nil
+		},
+	})
+	routes := CommandRoutes(reg, CommandRouteOptions{
+		AdminID:  1,
+		AdminIDs: []int64{2},
+		OnAdminReject: func(tele.Context) error {
+			rejected++
+			return nil
+		},
+	})
+	if len(routes) != 1 {
+		t.Fatalf("routes = %d", len(routes))
+	}
+
+	for _, user := range []int64{1, 2, 3} {
+		if err := routes[0].Handler(textContext(t, user, "/ban 5")); err != nil {
+			t.Fatalf("user %d: %v", user, err)
+		}
+	}
+	if ran != 2 || rejected != 1 {
+		t.Fatalf("ran = %d, rejected = %d; want 2 and 1", ran, rejected)
+	}
+}
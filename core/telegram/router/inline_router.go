@@ -0,0 +1,132 @@
+package router
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tg "github.com/m3rciful/gobot/core/telegram"
+	"github.com/m3rciful/gobot/core/telegram/middleware"
+	"log/slog"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+const (
+	defaultInlinePageSize = 20
+	maxInlinePageSize     = 50
+)
+
+// InlineOptions controls fallback behaviour for inline updates.
+type InlineOptions struct {
+	// Unknown answers queries no route matches; without it an empty answer is sent.
+	Unknown tg.InlineHandler
+}
+
+// InlineRoutes builds handlers for inline queries and chosen inline results
+// registered with Registry.RegisterInline.
+func InlineRoutes(reg *tg.Registry, opts InlineOptions) []tg.Route {
+	query := func(c tele.Context) error {
+		start := time.Now()
+		q := c.Query()
+		if q == nil {
+			return nil
+		}
+		route, ok := reg.MatchInline(q.Text)
+		if !ok {
+			if opts.Unknown == nil {
+				logHandlerSummary(c, "inline.unknown", start, "skip", "ok", nil)
+				return c.Answer(&tele.QueryResponse{Results: tele.Results{}})
+			}
+			route = tg.InlineRoute{Handler: opts.Unknown}
+		}
+		name := inlineHandlerName(route.Prefix, ok)
+		offset, _ := strconv.Atoi(q.Offset)
+		if offset < 0 {
+			offset = 0
+		}
+		limit := route.PageSize
+		if limit <= 0 {
+			limit = defaultInlinePageSize
+		}
+		if limit > maxInlinePageSize {
+			limit = maxInlinePageSize
+		}
+		req := tg.InlineRequest{
+			Prefix: route.Prefix,
+			Text:   strings.TrimSpace(strings.TrimPrefix(q.Text, route.Prefix)),
+			Offset: offset,
+			Limit:  limit,
+		}
+		answer := func(c tele.Context) error {
+			ans, err := route.Handler(c, req)
+			if err != nil {
+				return err
+			}
+			if len(ans.Results) > limit {
+				ans.Results = ans.Results[:limit]
+			}
+			resp := &tele.QueryResponse{
+				Results:    ans.Results,
+				CacheTime:  route.CacheTime,
+				IsPersonal: route.Personal,
+			}
+			if resp.Results == nil {
+				resp.Results = tele.Results{}
+			}
+			if ans.CacheTime > 0 {
+				resp.CacheTime = ans.CacheTime
+			}
+			for i, res := range resp.Results {
+				if res.ResultID() == "" {
+					res.SetResultID(strconv.Itoa(offset + i))
+				}
+			}
+			if ans.More {
+				resp.NextOffset = strconv.Itoa(offset + len(resp.Results))
+			}
+			return c.Answer(resp)
+		}
+		return handleWithSummary(c, name, start, "", "", func() error {
+			return middleware.Chain(answer, reg.Middleware()...)(c)
+		}, slog.Int("offset", offset), slog.Bool("personal", route.Personal))
+	}
+
+	chosen := func(c tele.Context) error {
+		start := time.Now()
+		res := c.InlineResult()
+		if res == nil {
+			return nil
+		}
+		route, ok := reg.MatchInline(res.Query)
+		name := inlineHandlerName(route.Prefix, ok) + ".chosen"
+		if !ok || route.OnChosen == nil {
+			logHandlerSummary(c, name, start, "skip", "ok", nil, slog.String("result_id", res.ResultID))
+			return nil
+		}
+		return handleWithSummary(c, name, start, "", "", func() error {
+			return middleware.Chain(route.OnChosen, reg.Middleware()...)(c)
+		}, slog.String("result_id", res.ResultID))
+	}
+
+	return []tg.Route{
+		{
+			Endpoint: tele.OnQuery,
+			Handler:  middleware.RecoverMiddleware(middleware.LoggerMiddleware(query)),
+		},
+		{
+			Endpoint: tele.OnInlineResult,
+			Handler:  middleware.RecoverMiddleware(middleware.LoggerMiddleware(chosen)),
+		},
+	}
+}
+
+func inlineHandlerName(prefix string, matched bool) string {
+	switch {
+	case !matched:
+		return "inline.unknown"
+	case strings.TrimSpace(prefix) == "":
+		return "inline.default"
+	}
+	return "inline." + normalizeHandlerName(prefix)
+}
@@ -0,0 +1,44 @@
+package router
+
+import (
+	"testing"
+
+	tg "github.com/m3rciful/gobot/core/telegram"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestInlineRoutesApplyRegistryMiddleware(t *testing.T) {
+	var blocked, ran int
+	reg := tg.NewRegistry()
+	reg.Use(func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			blocked++
+			return nil
+		}
+	})
+	if err := reg.RegisterInline(tg.InlineRoute{
+		Handler: func(tele.Context, tg.InlineRequest) (tg.InlineAnswer, error) {
+			ran++
+			return tg.InlineAnswer{}, nil
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	bot, err := tele.NewBot(tele.Settings{Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := bot.NewContext(tele.Update{ID: 1, Query: &tele.Query{
+		ID:     "1",
+		Sender: &tele.User{ID: 2},
+		Text:   "cats",
+	}})
+	if err := InlineRoutes(reg, InlineOptions{})[0].Handler(c); err != nil {
+		t.Fatal(err)
+	}
+	if blocked != 1 || ran != 0 {
+		t.Fatalf("blocked = %d, ran = %d; want the middleware to stop the query", blocked, ran)
+	}
+}
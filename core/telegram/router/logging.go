@@ -6,17 +6,26 @@ import (
 	"time"
 
 	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/metrics"
 	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
 	"github.com/m3rciful/gobot/core/telegram/middleware"
+	"github.com/m3rciful/gobot/core/tracing"
 	"log/slog"
 
 	tele "gopkg.in/telebot.v4"
 )
 
 func handleWithSummary(c tele.Context, handlerName string, start time.Time, statusOverride, outcomeOverride string, fn func() error, extras ...slog.Attr) error {
-	tghelpers.WithHandler(c, handlerName)
+	ctx := tghelpers.WithHandler(c, handlerName)
+	spanCtx, span := tracing.Start(ctx, "handler."+handlerName, tracing.KindInternal,
+		tracing.String("handler", handlerName))
+	if span != nil {
+		tghelpers.StoreContext(c, spanCtx)
+		defer tghelpers.StoreContext(c, ctx)
+	}
 	err := fn()
 	logHandlerSummary(c, handlerName, start, statusOverride, outcomeOverride, err, extras...)
+	span.End(err)
 	return err
 }
 
@@ -41,7 +50,11 @@ func logHandlerSummary(c tele.Context, handlerName string, start time.Time, stat
 		}
 	}
 
-	duration := logger.RoundMS(time.Since(start)).Milliseconds()
+	elapsed := time.Since(start)
+	metrics.HandlerDuration.With(handlerName).Observe(elapsed.Seconds())
+	metrics.HandlerOutcomes.With(handlerName, outcome).Inc()
+
+	duration := logger.RoundMS(elapsed).Milliseconds()
 	attrs := []slog.Attr{
 		slog.String("status", status),
 		slog.String("handler", handlerName),
@@ -0,0 +1,73 @@
+package router
+
+import (
+	"time"
+
+	tg "github.com/m3rciful/gobot/core/telegram"
+	"github.com/m3rciful/gobot/core/telegram/middleware"
+	"github.com/m3rciful/gobot/core/telegram/ui"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// MediaOptions controls fallback behaviour for media and other non-text messages.
+type MediaOptions struct {
+	// UnknownMedia returns the handler for a kind without a registered handler;
+	// it may return nil to skip. ui.MediaFallbackProvider.UnknownMedia fits here.
+	UnknownMedia func(kind string) tele.HandlerFunc
+}
+
+// MediaOptionsFrom builds MediaOptions from a fallback provider when it
+// implements ui.MediaFallbackProvider.
+func MediaOptionsFrom(p ui.FallbackProvider) MediaOptions {
+	if mp, ok := p.(ui.MediaFallbackProvider); ok {
+		return MediaOptions{UnknownMedia: mp.UnknownMedia}
+	}
+	return MediaOptions{}
+}
+
+// MediaRoutes builds handlers for every tg.MediaKind except documents, which
+// TextRoutes already serves. Each update goes to the FSM when a flow is in
+// progress, then to the handler registered with Registry.RegisterMedia, then
+// to opts.UnknownMedia.
+func MediaRoutes(fsmMgr FSM, reg *tg.Registry, opts MediaOptions) []tg.Route {
+	var routes []tg.Route
+	for _, kind := range tg.MediaKinds() {
+		if kind == tg.MediaDocument {
+			continue
+		}
+		var unknown tele.HandlerFunc
+		if opts.UnknownMedia != nil {
+			unknown = opts.UnknownMedia(string(kind))
+		}
+		routes = append(routes, tg.Route{
+			Endpoint: kind.Endpoint(),
+			Handler:  middleware.RecoverMiddleware(middleware.LoggerMiddleware(mediaHandler(fsmMgr, reg, kind, unknown))),
+		})
+	}
+	return routes
+}
+
+func mediaHandler(fsmMgr FSM, reg *tg.Registry, kind tg.MediaKind, unknown tele.HandlerFunc) tele.HandlerFunc {
+	suffix := string(kind)
+	return func(c tele.Context) error {
+		start := time.Now()
+		if fsmMgr != nil && c.Sender() != nil && fsmMgr.InProgress(c.Sender().ID) {
+			return handleWithSummary(c, "fsm_"+suffix, start, "", "", func() error {
+				return fsmMgr.ManagerHandler(c)
+			})
+		}
+		if h, ok := reg.MediaHandler(kind); ok {
+			return handleWithSummary(c, "media."+suffix, start, "", "", func() error {
+				return middleware.Chain(h, reg.Middleware()...)(c)
+			})
+		}
+		if unknown != nil {
+			return handleWithSummary(c, "unexpected_"+suffix, start, "", "", func() error {
+				return unknown(c)
+			})
+		}
+		logHandlerSummary(c, "unexpected_"+suffix, start, "skip", "ok", nil)
+		return nil
+	}
+}
@@ -1,9 +1,11 @@
 package router
 
 import (
+	"log/slog"
 	"time"
 
 	tg "github.com/m3rciful/gobot/core/telegram"
+	"github.com/m3rciful/gobot/core/telegram/commands"
 	"github.com/m3rciful/gobot/core/telegram/middleware"
 
 	tele "gopkg.in/telebot.v4"
@@ -16,12 +18,14 @@ type FSM interface {
 }
 
 // TextOptions controls fallback behaviour for text/document updates.
+// Documents are handled like other media (see MediaRoutes).
 type TextOptions struct {
 	UnknownText     tele.HandlerFunc
 	UnknownDocument tele.HandlerFunc
 }
 
-// TextRoutes builds handlers for text and document routing.
+// TextRoutes builds handlers for text and document routing. Text is routed to
+// the FSM, then commands, then Registry text routes, then fallbacks.
 // The routes perform the same logic previously wired via RegisterTextRouter.
 func TextRoutes(fsmMgr FSM, reg *tg.Registry, opts TextOptions) []tg.Route {
 	handler := func(c tele.Context) error {
@@ -34,15 +38,35 @@ func TextRoutes(fsmMgr FSM, reg *tg.Registry, opts TextOptions) []tg.Route {
 			})
 		}
 
+		if _, ok := commands.ParseCommand(text, ""); ok {
+			if _, mine := commands.Invoke(c); !mine {
+				logHandlerSummary(c, "other_bot", start, "skip", "ok", nil)
+				return nil
+			}
+		}
+
 		if reg != nil {
-			if key, cmd, ok := reg.LookupCommand(text); ok && cmd.Handler != nil {
+			if key, cmd, ok := lookupInvocation(c, reg); ok && cmd.Handler != nil {
 				name := normalizeHandlerName(key)
+				h := reg.CommandHandler(cmd)
+				// Case variants and aliases reach this path instead of the
+				// guarded CommandRoutes handler, so check access here too.
+				if guard := reg.Guard(cmd.AdminOnly, cmd.Permissions); guard != nil {
+					h = guard(h)
+				}
 				return handleWithSummary(c, name, start, "", "", func() error {
-					return cmd.Handler(c)
+					return h(c)
 				})
 			}
 		}
 
+		if route, ok := reg.MatchText(c); ok {
+			name := "text." + normalizeHandlerName(route.Name)
+			return handleWithSummary(c, name, start, "", "", func() error {
+				return middleware.Chain(route.Handler, reg.Middleware()...)(c)
+			}, slog.String("match", route.Kind()))
+		}
+
 		if reg != nil {
 			if fb := reg.TextFallback(); fb != nil {
 				return handleWithSummary(c, "fallback", start, "", "", func() error {
@@ -61,21 +85,7 @@ func TextRoutes(fsmMgr FSM, reg *tg.Registry, opts TextOptions) []tg.Route {
 		return nil
 	}
 
-	docHandler := func(c tele.Context) error {
-		start := time.Now()
-		if fsmMgr != nil && fsmMgr.InProgress(c.Sender().ID) {
-			return handleWithSummary(c, "fsm_document", start, "", "", func() error {
-				return fsmMgr.ManagerHandler(c)
-			})
-		}
-		if opts.UnknownDocument != nil {
-			return handleWithSummary(c, "unexpected_document", start, "", "", func() error {
-				return opts.UnknownDocument(c)
-			})
-		}
-		logHandlerSummary(c, "unexpected_document", start, "skip", "ok", nil)
-		return nil
-	}
+	docHandler := mediaHandler(fsmMgr, reg, tg.MediaDocument, opts.UnknownDocument)
 
 	return []tg.Route{
 		{
@@ -88,3 +98,12 @@ func TextRoutes(fsmMgr FSM, reg *tg.Registry, opts TextOptions) []tg.Route {
 		},
 	}
 }
+
+// lookupInvocation resolves the command parsed for c by name or alias,
+// falling back to an exact match of the message text.
+func lookupInvocation(c tele.Context, reg *tg.Registry) (string, commands.Command, bool) {
+	if inv, ok := commands.InvocationFrom(c); ok {
+		return reg.LookupCommand(inv.Name)
+	}
+	return reg.LookupCommand(c.Text())
+}
@@ -0,0 +1,62 @@
+package router
+
+import (
+	"testing"
+
+	tg "github.com/m3rciful/gobot/core/telegram"
+	"github.com/m3rciful/gobot/core/telegram/commands"
+	"github.com/m3rciful/gobot/core/telegram/middleware"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func textContext(t *testing.T, userID int64, text string) tele.Context {
+	t.Helper()
+	bot, err := tele.NewBot(tele.Settings{Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bot.NewContext(tele.Update{ID: 1, Message: &tele.Message{
+		Sender: &tele.User{ID: userID},
+		Chat:   &tele.Chat{ID: userID, Type: tele.ChatPrivate},
+		Text:   text,
+	}})
+}
+
+func TestTextRoutesGuardCommands(t *testing.T) {
+	var ran, rejected int
+	reg := tg.NewRegistry()
+	reg.RegisterCommand("/ban", commands.Command{
+		Description: "Ban a user",
+		Aliases:     []string{"b"},
+		AdminOnly:   true,
+		Handler: func(tele.Context) error {
+			ran++
+			return nil
+		},
+	})
+	reg.SetAdminOptions(middleware.AdminOptions{
+		AdminID: 1,
+		OnReject: func(tele.Context) error {
+			rejected++
+			return nil
+		},
+	})
+	text := TextRoutes(nil, reg, TextOptions{})[0].Handler
+
+	for _, input := range []string{"/BAN 123", "/b 123", "/Ban"} {
+		if err := text(textContext(t, 2, input)); err != nil {
+			t.Fatalf("%q: %v", input, err)
+		}
+	}
+	if ran != 0 || rejected != 3 {
+		t.Fatalf("non-admin: ran = %d, rejected = %d", ran, rejected)
+	}
+
+	if err := text(textContext(t, 1, "/B 123")); err != nil {
+		t.Fatal(err)
+	}
+	if ran != 1 {
+		t.Fatalf("admin alias: ran = %d", ran)
+	}
+}
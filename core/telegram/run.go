@@ -6,13 +6,18 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/m3rciful/gobot/core/admin"
 	coreconfig "github.com/m3rciful/gobot/core/config"
 	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/metrics"
 	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
 	tgsender "github.com/m3rciful/gobot/core/telegram/sender"
+	"github.com/m3rciful/gobot/core/tracing"
 
 	tele "gopkg.in/telebot.v4"
 	"log/slog"
@@ -42,7 +47,20 @@ type RunOptions struct {
 	Middlewares []Middleware
 	Routes      []Route
 
-	DisableWebhookCleanup bool
+	// I18n overrides the message bundle; when nil, a bundle is built from
+	// Config.I18n.DefaultLocale and, if set, the catalogs in Config.I18n.Dir.
+	I18n *i18n.Bundle
+
+	// CommandMenu configures command menu publishing; the configured admins
+	// are added to AdminIDs automatically.
+	CommandMenu CommandMenuOptions
+
+	// DB, when set, is pinged by the admin server's /readyz.
+	DB admin.Pinger
+	// HealthChecks are added to the admin server's /readyz.
+	HealthChecks []admin.Check
+
+	DisableWebhookCleanup   bool
 	DisableHelperDispatcher bool
 
 	OnStart func(ctx context.Context, rt Runtime) error
@@ -53,6 +71,13 @@ type RunOptions struct {
 type Runtime struct {
 	Dispatcher *tgsender.Dispatcher
 	Registry   *Registry
+	// Webhook is set in webhook mode; mount it on your own server when
+	// webhook.external is enabled.
+	Webhook *Webhook
+	// Heartbeat tracks the last getUpdates response or webhook request.
+	Heartbeat *Heartbeat
+	// Admin is the admin HTTP server, nil unless admin.listen is set.
+	Admin *admin.Server
 }
 
 // RunTelegram composes and runs a Telegram bot until the provided context is done.
@@ -69,21 +94,69 @@ func RunTelegram(ctx context.Context, opts RunOptions) error {
 	if reg == nil {
 		reg = NewRegistry()
 	}
+	reg.useSigningKey(cfg.Telegram.SigningKey)
+
+	bundle := opts.I18n
+	if bundle == nil && strings.TrimSpace(cfg.I18n.Dir) != "" {
+		bundle = i18n.NewBundle(cfg.I18n.DefaultLocale)
+		if err := bundle.LoadDir(cfg.I18n.Dir); err != nil {
+			return fmt.Errorf("telegram: %w", err)
+		}
+	}
+	if bundle == nil && strings.TrimSpace(cfg.I18n.DefaultLocale) != "" {
+		// No catalogs, but the default locale still drives plural rules and
+		// the locale reported to handlers.
+		bundle = i18n.NewBundle(cfg.I18n.DefaultLocale)
+	}
+	if bundle != nil {
+		i18n.SetBundle(bundle)
+		logger.TWire.Info("tg.wire",
+			slog.String("event", "i18n"),
+			slog.String("default_locale", bundle.DefaultLocale()),
+			slog.String("locales", strings.Join(bundle.Locales(), ",")),
+		)
+	}
+
+	stopTracing := setupTracing(ctx, cfg.Tracing)
+	defer stopTracing()
 
-	poller := BuildPoller(PollerOptions{
+	wh := cfg.Webhook
+	poller, err := NewPoller(PollerOptions{
 		RunMode:                cfg.Telegram.RunMode,
 		LongPollTimeoutSeconds: cfg.Telegram.LongPollTimeoutSeconds,
 		Webhook: WebhookOptions{
-			Listen: cfg.Webhook.Listen,
-			Port:   cfg.Webhook.Port,
-			URL:    cfg.Webhook.URL,
+			Listen:             wh.Listen,
+			Port:               wh.Port,
+			URL:                wh.URL,
+			Path:               wh.Path,
+			SecretToken:        wh.SecretToken,
+			CertFile:           wh.CertFile,
+			KeyFile:            wh.KeyFile,
+			SelfSigned:         wh.SelfSigned,
+			AllowedUpdates:     wh.AllowedUpdates,
+			MaxConnections:     wh.MaxConnections,
+			DropPendingUpdates: wh.DropPendingUpdates,
+			RestrictIPs:        wh.RestrictIPs,
+			AllowedNetworks:    wh.AllowedNetworks,
+			TrustProxy:         wh.TrustProxy,
+			External:           wh.External,
 		},
 	})
+	if err != nil {
+		return err
+	}
 
+	heartbeat := &Heartbeat{}
+	client := BuildHTTPClient()
+	client.Transport = &pollProbe{base: client.Transport, heartbeat: heartbeat}
+	// Updates are counted by the poller so shutdown can wait for them;
+	// it runs each on its own goroutine, hence the synchronous bot.
+	tracker := &trackingPoller{Poller: poller, handlers: newInflight()}
 	settings := tele.Settings{
-		Token:  cfg.Telegram.Token,
-		Poller: poller,
-		Client: BuildHTTPClient(),
+		Token:       cfg.Telegram.Token,
+		Poller:      tracker,
+		Client:      client,
+		Synchronous: true,
 	}
 
 	buildStart := time.Now()
@@ -97,6 +170,14 @@ func RunTelegram(ctx context.Context, opts RunOptions) error {
 	if dispatcher == nil {
 		dispatcher = tgsender.NewDispatcher(opts.DispatcherOptions)
 	}
+	metrics.QueueDepth.Set(func() float64 {
+		queued, _ := dispatcher.QueueDepth()
+		return float64(queued)
+	})
+	metrics.QueueCapacity.Set(func() float64 {
+		_, capacity := dispatcher.QueueDepth()
+		return float64(capacity)
+	})
 	useHelperDispatcher := !opts.DisableHelperDispatcher
 	if useHelperDispatcher {
 		tghelpers.SetDispatcher(dispatcher)
@@ -105,16 +186,20 @@ func RunTelegram(ctx context.Context, opts RunOptions) error {
 	rt := Runtime{
 		Dispatcher: dispatcher,
 		Registry:   reg,
+		Heartbeat:  heartbeat,
 	}
 
 	// Log adapter configuration (INFO aggregates only)
 	switch p := poller.(type) {
-	case *tele.Webhook:
+	case *Webhook:
+		p.heartbeat = heartbeat
+		rt.Webhook = p
 		attrs := []slog.Attr{
 			slog.String("event", "mode"),
 			slog.String("mode", "webhook"),
-			slog.String("listen", p.Listen),
-			slog.String("public_url", p.Endpoint.PublicURL),
+			slog.String("listen", p.Listen()),
+			slog.String("path", p.Path()),
+			slog.String("public_url", p.PublicURL()),
 			slog.Duration("duration", logger.RoundMS(buildTook)),
 		}
 		logger.TG.LogAttrs(ctx, slog.LevelInfo, "webhook mode", attrs...)
@@ -160,10 +245,25 @@ func RunTelegram(ctx context.Context, opts RunOptions) error {
 		bot.Handle(route.Endpoint, route.Handler)
 	}
 
-	SetupCommands(bot, reg)
+	menuOpts := opts.CommandMenu
+	admins := append([]int64{cfg.Telegram.AdminID}, cfg.Telegram.AdminIDs...)
+	menuOpts.AdminIDs = append(admins, menuOpts.AdminIDs...)
+	SetupCommands(bot, reg, menuOpts)
+
+	if strings.TrimSpace(cfg.Admin.Listen) != "" {
+		rt.Admin = newAdminServer(cfg, opts, rt)
+		if err := rt.Admin.Start(); err != nil {
+			dispatcher.Close()
+			if useHelperDispatcher {
+				tghelpers.SetDispatcher(nil)
+			}
+			return fmt.Errorf("telegram: admin server: %w", err)
+		}
+	}
 
 	if opts.OnStart != nil {
 		if err := opts.OnStart(ctx, rt); err != nil {
+			shutdownAdmin(rt.Admin)
 			dispatcher.Close()
 			if useHelperDispatcher {
 				tghelpers.SetDispatcher(nil)
@@ -172,6 +272,11 @@ func RunTelegram(ctx context.Context, opts RunOptions) error {
 		}
 	}
 
+	var pollFailed <-chan error
+	if rt.Webhook != nil {
+		pollFailed = rt.Webhook.Failed()
+	}
+
 	runDone := make(chan struct{})
 	go func() {
 		bot.Start()
@@ -185,19 +290,30 @@ func RunTelegram(ctx context.Context, opts RunOptions) error {
 		bot.Stop()
 		<-runDone
 		runErr = ctx.Err()
+	case err := <-pollFailed:
+		bot.Stop()
+		<-runDone
+		runErr = err
 	case <-runDone:
 	}
 
+	timeout := defaultShutdownTimeout
+	if cfg.Telegram.ShutdownTimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.Telegram.ShutdownTimeoutSeconds) * time.Second
+	}
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), timeout)
+	drain(drainCtx, tracker, dispatcher).log(drainCtx)
+	cancelDrain()
+
 	var stopErr error
 	if opts.OnStop != nil {
-		stopCtx := ctx
-		if stopCtx == nil {
-			stopCtx = context.Background()
-		}
+		// ctx is already cancelled here; give OnStop a budget of its own.
+		stopCtx, cancelStop := context.WithTimeout(context.Background(), timeout)
 		stopErr = opts.OnStop(stopCtx, rt)
+		cancelStop()
 	}
+	shutdownAdmin(rt.Admin)
 
-	dispatcher.Close()
 	if useHelperDispatcher {
 		tghelpers.SetDispatcher(nil)
 	}
@@ -215,6 +331,45 @@ func RunTelegram(ctx context.Context, opts RunOptions) error {
 	return nil
 }
 
+func shutdownAdmin(srv *admin.Server) {
+	if srv == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = srv.Shutdown(ctx)
+}
+
+// setupTracing installs the configured span exporter and returns a function
+// that flushes it; both are no-ops when tracing is disabled.
+func setupTracing(ctx context.Context, cfg coreconfig.TracingConfig) func() {
+	var exp tracing.Exporter
+	switch cfg.Exporter {
+	case coreconfig.TracingStdout:
+		exp = tracing.NewStdoutExporter(os.Stdout)
+	case coreconfig.TracingOTLP:
+		exp = tracing.NewOTLPExporter(tracing.OTLPOptions{
+			Endpoint:    cfg.Endpoint,
+			Headers:     cfg.Headers,
+			ServiceName: cfg.ServiceName,
+		})
+	default:
+		return func() {}
+	}
+	shutdown := tracing.Setup(tracing.Options{Exporter: exp, SampleRatio: cfg.SampleRatio})
+	logger.Info(ctx, "tracing", "setup",
+		slog.String("exporter", cfg.Exporter),
+		slog.Float64("sample_ratio", cfg.SampleRatio),
+	)
+	return func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(flushCtx); err != nil {
+			logger.Warn(flushCtx, "tracing", "shutdown.fail", slog.String("err", err.Error()))
+		}
+	}
+}
+
 func deleteWebhook(token string, dropPending bool) error {
 	if strings.TrimSpace(token) == "" {
 		return fmt.Errorf("empty token")
@@ -16,7 +16,9 @@ import (
 	"time"
 
 	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/metrics"
 	"github.com/m3rciful/gobot/core/telegram/netutil"
+	"github.com/m3rciful/gobot/core/tracing"
 
 	tele "gopkg.in/telebot.v4"
 )
@@ -26,6 +28,8 @@ var (
 	ErrQueueClosed = errors.New("telegram sender: queue closed")
 	// ErrQueueFull indicates the queue is saturated and the job was not accepted.
 	ErrQueueFull = errors.New("telegram sender: queue full")
+	// ErrAbandoned marks a job whose retries were cut short by Shutdown.
+	ErrAbandoned = errors.New("telegram sender: abandoned at shutdown")
 
 	tokenRe = regexp.MustCompile(`bot[0-9]+:[A-Za-z0-9_-]+`)
 )
@@ -51,10 +55,16 @@ type job struct {
 type Dispatcher struct {
 	opts Options
 	jobs chan job
-	stop chan struct{}
-	once sync.Once
-	wg   sync.WaitGroup
-	errs atomic.Uint64
+	// mu guards closing jobs against concurrent Enqueue calls.
+	mu      sync.RWMutex
+	closed  bool
+	once    sync.Once
+	wg      sync.WaitGroup
+	errs    atomic.Uint64
+	running atomic.Int64
+	// abandon is closed when Shutdown gives up on the remaining jobs.
+	abandon     chan struct{}
+	abandonOnce sync.Once
 }
 
 // NewDispatcher starts a dispatcher with sane defaults if options are zeroed.
@@ -76,9 +86,9 @@ func NewDispatcher(opts Options) *Dispatcher {
 	}
 
 	d := &Dispatcher{
-		opts: opts,
-		jobs: make(chan job, opts.QueueSize),
-		stop: make(chan struct{}),
+		opts:    opts,
+		jobs:    make(chan job, opts.QueueSize),
+		abandon: make(chan struct{}),
 	}
 
 	d.wg.Add(opts.Workers)
@@ -95,10 +105,10 @@ func (d *Dispatcher) Enqueue(ctx context.Context, action, endpoint string, run f
 	if run == nil {
 		return errors.New("telegram sender: nil run function")
 	}
-	select {
-	case <-d.stop:
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
 		return ErrQueueClosed
-	default:
 	}
 
 	j := job{
@@ -121,19 +131,53 @@ func (d *Dispatcher) ErrorCount() uint64 {
 	return d.errs.Load()
 }
 
+// QueueDepth reports the number of queued jobs and the queue capacity.
+func (d *Dispatcher) QueueDepth() (queued, capacity int) {
+	return len(d.jobs), cap(d.jobs)
+}
+
 // Close stops workers and waits for them to finish processing queued jobs.
 func (d *Dispatcher) Close() {
+	_, _ = d.Shutdown(context.Background())
+}
+
+// Shutdown stops accepting jobs and waits for queued ones until ctx is done.
+// It then drops the jobs still queued, stops retrying the running ones and
+// returns how many jobs were abandoned together with ctx.Err().
+func (d *Dispatcher) Shutdown(ctx context.Context) (abandoned int, err error) {
 	d.once.Do(func() {
-		close(d.stop)
+		d.mu.Lock()
+		d.closed = true
 		close(d.jobs)
-		d.wg.Wait()
+		d.mu.Unlock()
 	})
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return 0, nil
+	case <-ctx.Done():
+	}
+	abandoned = len(d.jobs) + int(d.running.Load())
+	d.abandonOnce.Do(func() { close(d.abandon) })
+	return abandoned, ctx.Err()
 }
 
 func (d *Dispatcher) worker() {
 	defer d.wg.Done()
 	for j := range d.jobs {
+		select {
+		case <-d.abandon:
+			continue
+		default:
+		}
+		d.running.Add(1)
 		d.handleJob(j)
+		d.running.Add(-1)
 	}
 }
 
@@ -143,16 +187,23 @@ func (d *Dispatcher) handleJob(j job) {
 		ctx = context.Background()
 	}
 
+	var (
+		lastErr       error
+		failureLogged bool
+	)
+	attrs := []tracing.Attr{tracing.String("action", j.action)}
+	if j.endpoint != "" {
+		attrs = append(attrs, tracing.String("telegram.method", j.endpoint))
+	}
+	ctx, span := tracing.Start(ctx, "send."+j.action, tracing.KindInternal, attrs...)
+	defer func() { span.End(lastErr) }()
+
 	deadlineCtx, cancel := context.WithTimeout(ctx, d.opts.MaxDuration)
 	defer cancel()
 
 	start := time.Now()
 	logger.Debug(ctx, "tg.sender", "send.start", sendLogAttrs(ctx, j)...)
 
-	var (
-		lastErr       error
-		failureLogged bool
-	)
 	attempts := d.opts.MaxRetries + 1
 
 attemptLoop:
@@ -162,6 +213,7 @@ attemptLoop:
 			break
 		}
 
+		metrics.SendAttempts.With(j.action).Inc()
 		if err := j.run(); err != nil {
 			lastErr = err
 			if !netutil.ShouldRetry(err) || attempt == attempts {
@@ -179,6 +231,12 @@ attemptLoop:
 				logSendFailure(ctx, j, lastErr, attempts, time.Since(start))
 				failureLogged = true
 				break attemptLoop
+			case <-d.abandon:
+				timer.Stop()
+				lastErr = ErrAbandoned
+				logSendFailure(ctx, j, lastErr, attempts, time.Since(start))
+				failureLogged = true
+				break attemptLoop
 			case <-timer.C:
 			}
 			logger.Debug(ctx, "tg.sender", "send.retry.backoff",
@@ -199,12 +257,15 @@ attemptLoop:
 				)...,
 			)
 		}
+		lastErr = nil
+		span.SetAttrs(tracing.Int64("attempts", int64(attempt)))
 		logSendSuccess(ctx, j, attempt, time.Since(start))
 		return
 	}
 
 	if lastErr != nil {
 		d.errs.Add(1)
+		metrics.SendFailures.With(j.action, classifyError(lastErr)).Inc()
 		if !failureLogged {
 			logSendFailure(ctx, j, lastErr, attempts, time.Since(start))
 		}
@@ -269,6 +330,9 @@ func classifyError(err error) string {
 	if errors.Is(err, context.DeadlineExceeded) {
 		return "timeout"
 	}
+	if errors.Is(err, ErrAbandoned) {
+		return "abandoned"
+	}
 
 	var dnsErr *net.DNSError
 	if errors.As(err, &dnsErr) {
@@ -1,8 +1,250 @@
 package telegram
 
-import tele "gopkg.in/telebot.v4"
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/telegram/commands"
+	"github.com/m3rciful/gobot/core/telegram/i18n"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// CommandMenuOptions controls how SetupCommands publishes command menus.
+type CommandMenuOptions struct {
+	// AdminIDs receive a chat-scoped menu that also lists AdminOnly and
+	// permission-guarded commands. Menus of admins dropped from the list are
+	// deleted on the next SetupCommands call with the same Registry; menus
+	// published before a restart are not known and stay until removed by hand.
+	AdminIDs []int64
+	// DisableDiff forces setMyCommands calls even when the published menu is unchanged.
+	DisableDiff bool
+}
+
+// publishedMenu identifies a menu set by SetupCommands.
+type publishedMenu struct {
+	scope tele.CommandScope
+	lang  string
+}
+
+type menuScope struct {
+	name    string
+	scope   tele.CommandScope
+	include func(commands.Command) bool
+}
 
 // SetupCommands registers bot command menu and related scopes.
-func SetupCommands(bot *tele.Bot, reg *Registry) {
-	InitBotCommands(bot, reg)
+// Menus are published for the default scope, all private chats, all group
+// chats and each admin chat, once per catalog language. Each menu is compared
+// with getMyCommands first so unchanged menus cost no write calls. Admin chat
+// and language menus published by an earlier call but no longer wanted are
+// deleted.
+func SetupCommands(bot *tele.Bot, reg *Registry, opts ...CommandMenuOptions) {
+	if bot == nil || reg == nil {
+		return
+	}
+	var o CommandMenuOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	scopes := []menuScope{
+		{
+			name:    tele.CommandScopeDefault,
+			scope:   tele.CommandScope{Type: tele.CommandScopeDefault},
+			include: func(c commands.Command) bool { return isPublic(c) && c.Chats == commands.ChatsAll },
+		},
+		{
+			name:    tele.CommandScopeAllPrivateChats,
+			scope:   tele.CommandScope{Type: tele.CommandScopeAllPrivateChats},
+			include: func(c commands.Command) bool { return isPublic(c) && c.Chats != commands.ChatsGroups },
+		},
+		{
+			name:    tele.CommandScopeAllGroupChats,
+			scope:   tele.CommandScope{Type: tele.CommandScopeAllGroupChats},
+			include: func(c commands.Command) bool { return isPublic(c) && c.Chats != commands.ChatsPrivate },
+		},
+	}
+	seen := make(map[int64]struct{}, len(o.AdminIDs))
+	for _, id := range o.AdminIDs {
+		if _, dup := seen[id]; dup || id == 0 {
+			continue
+		}
+		seen[id] = struct{}{}
+		scopes = append(scopes, menuScope{
+			name:    tele.CommandScopeChat,
+			scope:   tele.CommandScope{Type: tele.CommandScopeChat, ChatID: id},
+			include: func(c commands.Command) bool { return c.Chats != commands.ChatsGroups },
+		})
+	}
+
+	bundle := i18n.CurrentBundle()
+	var languages []string
+	for _, locale := range bundle.Locales() {
+		if locale != bundle.DefaultLocale() && len(locale) == 2 {
+			languages = append(languages, locale)
+		}
+	}
+
+	reg.menusMu.Lock()
+	defer reg.menusMu.Unlock()
+	published := make(map[publishedMenu]struct{})
+	var set, unchanged, deleted, failed int
+	for _, sc := range scopes {
+		base := reg.menuCommands(sc.include)
+		menus := map[string][]tele.Command{"": reg.localizeCommands(base, bundle, bundle.DefaultLocale())}
+		for _, lang := range languages {
+			if !reg.hasTranslations(base, bundle, lang) {
+				continue
+			}
+			menus[lang] = reg.localizeCommands(base, bundle, lang)
+		}
+		for lang, list := range menus {
+			published[publishedMenu{scope: sc.scope, lang: lang}] = struct{}{}
+			action, err := publishMenu(bot, sc.scope, lang, list, !o.DisableDiff)
+			if err != nil {
+				failed++
+				logger.TWire.LogAttrs(context.Background(), slog.LevelError, "register.commands.set_failed",
+					slog.String("scope", sc.name),
+					slog.Int64("chat_id", sc.scope.ChatID),
+					slog.String("lang", lang),
+					slog.String("err", err.Error()),
+				)
+				continue
+			}
+			switch action {
+			case menuSet:
+				set++
+			case menuDeleted:
+				deleted++
+			default:
+				unchanged++
+			}
+		}
+	}
+
+	for menu := range reg.menus {
+		if _, ok := published[menu]; ok {
+			continue
+		}
+		if err := bot.DeleteCommands(menu.scope, menu.lang); err != nil {
+			failed++
+			published[menu] = struct{}{}
+			logger.TWire.LogAttrs(context.Background(), slog.LevelError, "register.commands.delete_failed",
+				slog.String("scope", menu.scope.Type),
+				slog.Int64("chat_id", menu.scope.ChatID),
+				slog.String("lang", menu.lang),
+				slog.String("err", err.Error()),
+			)
+			continue
+		}
+		deleted++
+	}
+	reg.menus = published
+
+	logger.TWire.Info("tg.wire",
+		slog.String("event", "commands"),
+		slog.Int("scopes", len(scopes)),
+		slog.Int("languages", len(languages)+1),
+		slog.Int("set", set),
+		slog.Int("deleted", deleted),
+		slog.Int("unchanged", unchanged),
+		slog.Int("failed", failed),
+	)
+}
+
+// InitBotCommands sets the Telegram bot commands shown in the command menu.
+//
+// Deprecated: use SetupCommands, which also publishes scoped and localized menus.
+func InitBotCommands(bot *tele.Bot, reg *Registry) {
+	SetupCommands(bot, reg)
+}
+
+const (
+	menuUnchanged = "unchanged"
+	menuSet       = "set"
+	menuDeleted   = "deleted"
+)
+
+func publishMenu(bot *tele.Bot, scope tele.CommandScope, lang string, list []tele.Command, diff bool) (string, error) {
+	if diff {
+		current, err := bot.Commands(scope, lang)
+		if err == nil && sameCommands(current, list) {
+			return menuUnchanged, nil
+		}
+	}
+	if len(list) == 0 {
+		if err := bot.DeleteCommands(scope, lang); err != nil {
+			return "", err
+		}
+		return menuDeleted, nil
+	}
+	if err := bot.SetCommands(list, scope, lang); err != nil {
+		return "", err
+	}
+	return menuSet, nil
+}
+
+func sameCommands(a, b []tele.Command) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if strings.TrimPrefix(a[i].Text, "/") != strings.TrimPrefix(b[i].Text, "/") || a[i].Description != b[i].Description {
+			return false
+		}
+	}
+	return true
+}
+
+// menuCommands lists non-hidden commands accepted by include, sorted by name,
+// with the leading slash stripped as required by setMyCommands.
+func (r *Registry) menuCommands(include func(commands.Command) bool) []tele.Command {
+	var list []tele.Command
+	for name, meta := range r.commands {
+		if meta.Hidden || !include(meta) {
+			continue
+		}
+		list = append(list, tele.Command{Text: strings.TrimPrefix(name, "/"), Description: meta.Description})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Text < list[j].Text })
+	return list
+}
+
+// localizeCommands translates descriptions for locale, keeping Description as fallback.
+func (r *Registry) localizeCommands(list []tele.Command, bundle *i18n.Bundle, locale string) []tele.Command {
+	out := make([]tele.Command, len(list))
+	for i, item := range list {
+		out[i] = item
+		key := commandDescriptionKey(item.Text, r.commands["/"+item.Text])
+		if text := bundle.Translate(locale, key); text != key {
+			out[i].Description = text
+		}
+	}
+	return out
+}
+
+// hasTranslations reports whether locale itself provides any description in list.
+func (r *Registry) hasTranslations(list []tele.Command, bundle *i18n.Bundle, locale string) bool {
+	for _, item := range list {
+		if bundle.Has(locale, commandDescriptionKey(item.Text, r.commands["/"+item.Text])) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPublic reports whether a command is usable without any role.
+func isPublic(c commands.Command) bool {
+	return !c.AdminOnly && len(c.Permissions) == 0
+}
+
+func commandDescriptionKey(name string, cmd commands.Command) string {
+	if cmd.DescriptionKey != "" {
+		return cmd.DescriptionKey
+	}
+	return "commands." + strings.TrimPrefix(name, "/")
 }
@@ -0,0 +1,50 @@
+package telegram
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/telegram/commands"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestSetupCommandsDeletesDroppedAdminMenus(t *testing.T) {
+	logger.TWire = slog.New(slog.NewTextHandler(io.Discard, nil))
+	var mu sync.Mutex
+	var deletes []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if path.Base(r.URL.Path) == "deleteMyCommands" {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			deletes = append(deletes, string(body))
+			mu.Unlock()
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer api.Close()
+	bot, err := tele.NewBot(tele.Settings{URL: api.URL, Token: "test", Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := NewRegistry()
+	reg.RegisterCommand("/start", commands.Command{Description: "Start", Handler: func(tele.Context) error { return nil }})
+
+	SetupCommands(bot, reg, CommandMenuOptions{AdminIDs: []int64{1, 2}, DisableDiff: true})
+	if len(deletes) != 0 {
+		t.Fatalf("first setup deleted %v", deletes)
+	}
+	SetupCommands(bot, reg, CommandMenuOptions{AdminIDs: []int64{1}, DisableDiff: true})
+	mu.Lock()
+	defer mu.Unlock()
+	if len(deletes) != 1 || !strings.Contains(deletes[0], `"chat_id":2`) {
+		t.Fatalf("deletes = %v, want the menu of admin 2", deletes)
+	}
+}
@@ -0,0 +1,162 @@
+package telegram
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3rciful/gobot/core/logger"
+	tgsender "github.com/m3rciful/gobot/core/telegram/sender"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// defaultShutdownTimeout applies when telegram.shutdown_timeout_seconds is 0.
+const defaultShutdownTimeout = 15 * time.Second
+
+// inflight counts updates being handled so shutdown can wait for them.
+type inflight struct {
+	mu      sync.Mutex
+	updates map[int]int
+	idle    chan struct{}
+}
+
+func newInflight() *inflight {
+	return &inflight{updates: make(map[int]int)}
+}
+
+// trackingPoller wraps a poller and handles every update it delivers on a
+// goroutine of its own. The update is counted before that goroutine starts,
+// so once Bot.Stop returns every accepted update is visible to wait. The bot
+// must be synchronous for ProcessUpdate to return after its handlers.
+type trackingPoller struct {
+	tele.Poller
+	handlers *inflight
+
+	mu      sync.Mutex
+	pending int
+}
+
+// Poll runs the wrapped poller and dispatches its updates until it returns.
+func (p *trackingPoller) Poll(b *tele.Bot, _ chan tele.Update, stop chan struct{}) {
+	updates := make(chan tele.Update)
+	done := make(chan struct{})
+	go func() {
+		p.Poller.Poll(b, updates, stop)
+		close(done)
+	}()
+	for {
+		select {
+		case upd := <-updates:
+			select {
+			case <-stop:
+				// Accepted after Stop: still handled, reported by drain.
+				p.mu.Lock()
+				p.pending++
+				p.mu.Unlock()
+			default:
+			}
+			p.handlers.begin(upd.ID)
+			go func() {
+				defer p.handlers.end(upd.ID)
+				b.ProcessUpdate(upd)
+			}()
+		case <-done:
+			return
+		}
+	}
+}
+
+// accepted returns how many updates arrived after the bot was asked to stop.
+func (p *trackingPoller) accepted() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.pending
+}
+
+func (t *inflight) begin(id int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.updates) == 0 {
+		t.idle = make(chan struct{})
+	}
+	t.updates[id]++
+}
+
+func (t *inflight) end(id int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.updates[id]--; t.updates[id] <= 0 {
+		delete(t.updates, id)
+	}
+	if len(t.updates) == 0 && t.idle != nil {
+		close(t.idle)
+		t.idle = nil
+	}
+}
+
+// wait blocks until no handler runs or ctx is done, and returns the IDs of
+// the updates whose handlers were still running.
+func (t *inflight) wait(ctx context.Context) []int {
+	for {
+		t.mu.Lock()
+		idle := t.idle
+		if idle == nil {
+			t.mu.Unlock()
+			return nil
+		}
+		t.mu.Unlock()
+		select {
+		case <-idle:
+		case <-ctx.Done():
+			t.mu.Lock()
+			ids := make([]int, 0, len(t.updates))
+			for id := range t.updates {
+				ids = append(ids, id)
+			}
+			t.mu.Unlock()
+			sort.Ints(ids)
+			return ids
+		}
+	}
+}
+
+// shutdownReport describes what a drain finished and what it gave up on.
+type shutdownReport struct {
+	pending          int
+	abandonedUpdates []int
+	abandonedSends   int
+	duration         time.Duration
+}
+
+// drain waits for the updates a stopped bot had accepted to be handled,
+// then for queued sends, until ctx is done. Handlers run before the
+// dispatcher closes, so sends they enqueue while finishing are delivered.
+func drain(ctx context.Context, poller *trackingPoller, dispatcher *tgsender.Dispatcher) shutdownReport {
+	start := time.Now()
+	report := shutdownReport{pending: poller.accepted()}
+
+	report.abandonedUpdates = poller.handlers.wait(ctx)
+	report.abandonedSends, _ = dispatcher.Shutdown(ctx)
+	report.duration = time.Since(start)
+	return report
+}
+
+func (r shutdownReport) log(ctx context.Context) {
+	attrs := []slog.Attr{
+		slog.Int("pending_updates", r.pending),
+		slog.Int64("duration_ms", logger.RoundMS(r.duration).Milliseconds()),
+	}
+	if len(r.abandonedUpdates) == 0 && r.abandonedSends == 0 {
+		logger.Info(ctx, "tg", "shutdown.drained", attrs...)
+		return
+	}
+	attrs = append(attrs,
+		slog.Int("abandoned_handlers", len(r.abandonedUpdates)),
+		slog.Any("abandoned_update_ids", r.abandonedUpdates),
+		slog.Int("abandoned_sends", r.abandonedSends),
+	)
+	logger.Warn(ctx, "tg", "shutdown.abandoned", attrs...)
+}
@@ -0,0 +1,124 @@
+package telegram
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	tgsender "github.com/m3rciful/gobot/core/telegram/sender"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// lateUpdatePoller delivers one update only after the bot is asked to stop,
+// like a long poll that returns while Stop is in progress.
+type lateUpdatePoller struct {
+	update tele.Update
+}
+
+func (p lateUpdatePoller) Poll(_ *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	<-stop
+	dest <- p.update
+}
+
+func TestDrainHandlesPendingUpdateSends(t *testing.T) {
+	var mu sync.Mutex
+	var methods []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, path.Base(r.URL.Path))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":1,"chat":{"id":1}}}`))
+	}))
+	defer api.Close()
+
+	tracker := &trackingPoller{
+		Poller: lateUpdatePoller{update: tele.Update{ID: 9, Message: &tele.Message{
+			Sender: &tele.User{ID: 1},
+			Chat:   &tele.Chat{ID: 1, Type: tele.ChatPrivate},
+			Text:   "bye",
+		}}},
+		handlers: newInflight(),
+	}
+	bot, err := tele.NewBot(tele.Settings{URL: api.URL, Token: "test", Offline: true, Synchronous: true, Poller: tracker})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := tgsender.NewDispatcher(tgsender.Options{Workers: 1})
+	bot.Handle(tele.OnText, func(c tele.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		return d.Enqueue(context.Background(), "send", "", func() error {
+			_, err := bot.Send(c.Chat(), "goodbye")
+			return err
+		})
+	})
+
+	go bot.Start()
+	time.Sleep(10 * time.Millisecond)
+	bot.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	report := drain(ctx, tracker, d)
+	if report.pending != 1 || len(report.abandonedUpdates) != 0 || report.abandonedSends != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(methods) != 1 || methods[0] != "sendMessage" {
+		t.Fatalf("api calls = %v, want [sendMessage]", methods)
+	}
+}
+
+func TestInflightWait(t *testing.T) {
+	handlers := newInflight()
+	if ids := handlers.wait(context.Background()); ids != nil {
+		t.Fatalf("idle wait = %v", ids)
+	}
+
+	handlers.begin(1)
+	handlers.begin(2)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		handlers.end(1)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if ids := handlers.wait(ctx); len(ids) != 1 || ids[0] != 2 {
+		t.Fatalf("abandoned = %v, want [2]", ids)
+	}
+
+	handlers.end(2)
+	if ids := handlers.wait(context.Background()); ids != nil {
+		t.Fatalf("wait after end = %v", ids)
+	}
+}
+
+func TestDispatcherShutdownAbandons(t *testing.T) {
+	d := tgsender.NewDispatcher(tgsender.Options{Workers: 1, QueueSize: 4})
+	release := make(chan struct{})
+	defer close(release)
+	for i := 0; i < 3; i++ {
+		if err := d.Enqueue(context.Background(), "send", "", func() error {
+			<-release
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	abandoned, err := d.Shutdown(ctx)
+	if err == nil || abandoned != 3 {
+		t.Fatalf("Shutdown = %d, %v; want 3 abandoned", abandoned, err)
+	}
+	if err := d.Enqueue(context.Background(), "send", "", func() error { return nil }); err != tgsender.ErrQueueClosed {
+		t.Fatalf("Enqueue after shutdown = %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/metrics"
 	tghelpers "github.com/m3rciful/gobot/core/telegram/helpers"
 	"log/slog"
 
@@ -41,9 +42,10 @@ func (m *memoryManager) Set(userID int64, state State) {
 
 	session, ok := m.sessions[userID]
 	if !ok {
-		session = &Session{TempData: make(map[string]interface{})}
+		session = &Session{State: StateIdle, TempData: make(map[string]interface{})}
 		m.sessions[userID] = session
 	}
+	recordTransition(session.State, state)
 	session.State = state
 }
 
@@ -101,6 +103,9 @@ func (m *memoryManager) Clear(userID int64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if sess, ok := m.sessions[userID]; ok {
+		recordTransition(sess.State, StateIdle)
+	}
 	delete(m.sessions, userID)
 }
 
@@ -110,9 +115,10 @@ func (m *memoryManager) SetState(userID int64, st State) {
 	defer m.mu.Unlock()
 	sess, ok := m.sessions[userID]
 	if !ok {
-		sess = &Session{TempData: make(map[string]interface{})}
+		sess = &Session{State: StateIdle, TempData: make(map[string]interface{})}
 		m.sessions[userID] = sess
 	}
+	recordTransition(sess.State, st)
 	sess.State = st
 }
 
@@ -131,6 +137,7 @@ func (m *memoryManager) ClearState(userID int64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	if sess, ok := m.sessions[userID]; ok {
+		recordTransition(sess.State, StateIdle)
 		sess.State = StateIdle
 	}
 }
@@ -164,3 +171,13 @@ func (m *memoryManager) ManagerHandler(c tele.Context) error {
 	}
 	return nil
 }
+
+// recordTransition counts a state change in metrics.FSMTransitions.
+func recordTransition(from, to State) {
+	if from == "" {
+		from = StateIdle
+	}
+	if from != to {
+		metrics.FSMTransitions.With(string(from), string(to)).Inc()
+	}
+}
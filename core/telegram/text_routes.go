@@ -0,0 +1,163 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/m3rciful/gobot/core/logger"
+	"github.com/m3rciful/gobot/core/telegram/middleware"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// TextRoute matches plain text messages that are not commands. Exactly one of
+// Exact, Prefix, Pattern or Match must be set.
+type TextRoute struct {
+	// Name is logged as the handler name ("text.<name>"); defaults to the match kind.
+	Name string
+	// Exact matches the whole trimmed text.
+	Exact string
+	// Prefix matches the start of the text; the remainder is captured as "rest".
+	Prefix string
+	// Pattern captures named groups by name and all groups by index ("0" is the whole match).
+	Pattern *regexp.Regexp
+	// Match is an arbitrary predicate.
+	Match func(c tele.Context) bool
+	// IgnoreCase applies to Exact and Prefix.
+	IgnoreCase bool
+	// Priority orders routes, highest first; equal priorities keep registration order.
+	Priority int
+
+	Handler    tele.HandlerFunc
+	Middleware []tele.MiddlewareFunc
+}
+
+// Captures holds values extracted by a matching TextRoute.
+type Captures map[string]string
+
+const capturesKey = "text_captures"
+
+// TextCaptures returns the captures of the text route handling c.
+func TextCaptures(c tele.Context) Captures {
+	caps, _ := c.Get(capturesKey).(Captures)
+	return caps
+}
+
+// Kind reports how the route matches: exact, prefix, regex or match.
+func (t TextRoute) Kind() string {
+	switch {
+	case t.Exact != "":
+		return "exact"
+	case t.Prefix != "":
+		return "prefix"
+	case t.Pattern != nil:
+		return "regex"
+	case t.Match != nil:
+		return "match"
+	}
+	return ""
+}
+
+func (t TextRoute) matchers() int {
+	n := 0
+	for _, set := range []bool{t.Exact != "", t.Prefix != "", t.Pattern != nil, t.Match != nil} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// match reports whether the route accepts c and returns its captures.
+func (t TextRoute) match(c tele.Context, text string) (Captures, bool) {
+	switch {
+	case t.Exact != "":
+		text = strings.TrimSpace(text)
+		if text == t.Exact || (t.IgnoreCase && strings.EqualFold(text, t.Exact)) {
+			return Captures{}, true
+		}
+	case t.Prefix != "":
+		if len(text) < len(t.Prefix) {
+			return nil, false
+		}
+		head := text[:len(t.Prefix)]
+		if head == t.Prefix || (t.IgnoreCase && strings.EqualFold(head, t.Prefix)) {
+			return Captures{"rest": strings.TrimSpace(text[len(t.Prefix):])}, true
+		}
+	case t.Pattern != nil:
+		m := t.Pattern.FindStringSubmatch(text)
+		if m == nil {
+			return nil, false
+		}
+		caps := make(Captures, len(m)*2)
+		for i, name := range t.Pattern.SubexpNames() {
+			caps[strconv.Itoa(i)] = m[i]
+			if name != "" {
+				caps[name] = m[i]
+			}
+		}
+		return caps, true
+	case t.Match != nil:
+		if t.Match(c) {
+			return Captures{}, true
+		}
+	}
+	return nil, false
+}
+
+// RegisterTextRoute adds a text route consulted by TextRoutes after commands
+// and before the text fallback.
+func (r *Registry) RegisterTextRoute(route TextRoute) error {
+	if r == nil || route.Handler == nil || route.matchers() != 1 {
+		logger.TWire.LogAttrs(context.Background(), slog.LevelWarn, "register.text.skip",
+			slog.String("name", route.Name),
+			slog.Bool("handler_nil", route.Handler == nil),
+		)
+		return errors.New("invalid text route registration")
+	}
+	if route.Name == "" {
+		route.Name = route.Kind()
+	}
+	route.Handler = middleware.Chain(route.Handler, route.Middleware...)
+
+	r.textMu.Lock()
+	defer r.textMu.Unlock()
+	i := sort.Search(len(r.textRoutes), func(i int) bool {
+		return r.textRoutes[i].Priority < route.Priority
+	})
+	// Build a new slice so MatchText can keep using the one it read.
+	routes := make([]TextRoute, 0, len(r.textRoutes)+1)
+	routes = append(routes, r.textRoutes[:i]...)
+	routes = append(routes, route)
+	r.textRoutes = append(routes, r.textRoutes[i:]...)
+	return nil
+}
+
+// MatchText returns the first text route accepting the message in c and
+// stores its captures for TextCaptures. Text starting with "/" is left to
+// command handling and never matches, even when no such command exists.
+// Match predicates run without holding the registry lock.
+func (r *Registry) MatchText(c tele.Context) (TextRoute, bool) {
+	if r == nil {
+		return TextRoute{}, false
+	}
+	text := c.Text()
+	if strings.HasPrefix(text, "/") {
+		return TextRoute{}, false
+	}
+	r.textMu.RLock()
+	routes := r.textRoutes
+	r.textMu.RUnlock()
+	for _, route := range routes {
+		if caps, ok := route.match(c, text); ok {
+			c.Set(capturesKey, caps)
+			return route, true
+		}
+	}
+	return TextRoute{}, false
+}
@@ -0,0 +1,75 @@
+package telegram
+
+import (
+	"regexp"
+	"testing"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestTextRouteMatch(t *testing.T) {
+	order := TextRoute{Pattern: regexp.MustCompile(`^order #(?P<id>\d+)$`)}
+	caps, ok := order.match(nil, "order #123")
+	if !ok || caps["id"] != "123" || caps["0"] != "order #123" {
+		t.Fatalf("regex captures = %v, %v", caps, ok)
+	}
+
+	prefix := TextRoute{Prefix: "find ", IgnoreCase: true}
+	caps, ok = prefix.match(nil, "Find  red shoes")
+	if !ok || caps["rest"] != "red shoes" {
+		t.Fatalf("prefix captures = %v, %v", caps, ok)
+	}
+
+	if _, ok := (TextRoute{Exact: "hi"}).match(nil, "Hi"); ok {
+		t.Fatal("exact match should be case-sensitive by default")
+	}
+}
+
+func TestRegisterTextRoutePriority(t *testing.T) {
+	r := NewRegistry()
+	nopHandler := func(tele.Context) error { return nil }
+	for i, p := range []int{0, 10, 0, 5} {
+		if err := r.RegisterTextRoute(TextRoute{Name: string(rune('a' + i)), Exact: "x", Priority: p, Handler: nopHandler}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var got string
+	for _, route := range r.textRoutes {
+		got += route.Name
+	}
+	if got != "bdac" {
+		t.Fatalf("order = %q, want %q", got, "bdac")
+	}
+}
+
+func TestMatchTextSkipsCommands(t *testing.T) {
+	r := NewRegistry()
+	nopHandler := func(tele.Context) error { return nil }
+	if err := r.RegisterTextRoute(TextRoute{Match: func(tele.Context) bool { return true }, Handler: nopHandler}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.MatchText(commandContext(t, 1, "/foo", "")); ok {
+		t.Fatal("unregistered command matched a text route")
+	}
+	if _, ok := r.MatchText(commandContext(t, 1, "foo", "")); !ok {
+		t.Fatal("plain text did not match")
+	}
+}
+
+func TestMatchTextRunsPredicatesUnlocked(t *testing.T) {
+	r := NewRegistry()
+	nopHandler := func(tele.Context) error { return nil }
+	err := r.RegisterTextRoute(TextRoute{Match: func(tele.Context) bool {
+		// Registering from a predicate deadlocks if MatchText holds the lock.
+		return r.RegisterTextRoute(TextRoute{Exact: "later", Handler: nopHandler}) == nil
+	}, Handler: nopHandler})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.MatchText(commandContext(t, 1, "hi", "")); !ok {
+		t.Fatal("predicate route did not match")
+	}
+	if len(r.textRoutes) != 2 {
+		t.Fatalf("routes = %d, want 2", len(r.textRoutes))
+	}
+}
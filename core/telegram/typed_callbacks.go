@@ -0,0 +1,88 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// unsignedCodec serves typed callbacks until a codec is set; it is not
+// stored on the registry so a signing key installed later still applies.
+var unsignedCodec = callbacks.NewCodec(callbacks.CodecOptions{})
+
+// SetCallbackCodec sets the codec used by typed callbacks; RunTelegram
+// installs one signed with telegram.signing_key when none was set.
+func (r *Registry) SetCallbackCodec(codec *callbacks.Codec) {
+	r.callbacksMu.Lock()
+	defer r.callbacksMu.Unlock()
+	r.codec = codec
+}
+
+// CallbackCodec returns the codec used by typed callbacks (unsigned by default).
+func (r *Registry) CallbackCodec() *callbacks.Codec {
+	r.callbacksMu.RLock()
+	defer r.callbacksMu.RUnlock()
+	if r.codec == nil {
+		return unsignedCodec
+	}
+	return r.codec
+}
+
+// useSigningKey installs a codec signed with key unless one was set explicitly.
+func (r *Registry) useSigningKey(key string) {
+	if key == "" {
+		return
+	}
+	r.callbacksMu.Lock()
+	defer r.callbacksMu.Unlock()
+	if r.codec == nil {
+		r.codec = callbacks.NewCodec(callbacks.CodecOptions{Key: key})
+	}
+}
+
+// RegisterTypedCallback registers a callback whose payload is a T encoded by
+// the registry codec. Bumping version invalidates buttons sent with older
+// layouts of T. Payloads that fail verification are rejected with
+// callbacks.ErrTampered, ErrStale or ErrMalformed before handler runs.
+func RegisterTypedCallback[T any](r *Registry, key string, version uint, handler func(c tele.Context, data T) error, mws ...tele.MiddlewareFunc) error {
+	if handler == nil {
+		return r.RegisterCallback(key, nil)
+	}
+	err := r.RegisterCallback(key, func(c tele.Context) error {
+		var data T
+		if err := r.CallbackCodec().Decode(key, callbacks.CallbackPayload(c), version, &data); err != nil {
+			return err
+		}
+		return handler(c, data)
+	}, mws...)
+	if err != nil {
+		return err
+	}
+	r.callbacksMu.Lock()
+	r.typedVersions[key] = version
+	r.callbacksMu.Unlock()
+	return nil
+}
+
+// TypedButton builds an inline button for a callback registered with
+// RegisterTypedCallback, encoding data with the registry codec. Payloads
+// that are too long go to the payload store when one is set.
+func TypedButton[T any](r *Registry, markup *tele.ReplyMarkup, text, key string, data T) (tele.Btn, error) {
+	r.callbacksMu.RLock()
+	version, ok := r.typedVersions[key]
+	r.callbacksMu.RUnlock()
+	if !ok {
+		return tele.Btn{}, fmt.Errorf("typed callback not registered: %s", key)
+	}
+	payload, err := r.CallbackCodec().Encode(key, version, data)
+	if err != nil {
+		return tele.Btn{}, err
+	}
+	if payload, err = r.fitPayload(context.Background(), key, payload, 0); err != nil {
+		return tele.Btn{}, err
+	}
+	return markup.Data(text, key, payload), nil
+}
@@ -0,0 +1,40 @@
+package telegram
+
+import (
+	"testing"
+
+	"github.com/m3rciful/gobot/core/telegram/callbacks"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+type pageData struct {
+	Page int
+}
+
+func TestSigningKeyAppliesAfterEarlyCodecUse(t *testing.T) {
+	r := NewRegistry()
+	if err := RegisterTypedCallback(r, "page", 1, func(tele.Context, pageData) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	// Keyboards are often built at registration time, before RunTelegram.
+	if r.CallbackCodec().Signed() {
+		t.Fatal("default codec is signed")
+	}
+	if _, err := TypedButton(r, &tele.ReplyMarkup{}, "Next", "page", pageData{Page: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	r.useSigningKey("secret")
+	if !r.CallbackCodec().Signed() {
+		t.Fatal("signing key ignored after the codec was used")
+	}
+
+	explicit := NewRegistry()
+	custom := callbacks.NewCodec(callbacks.CodecOptions{Key: "custom"})
+	explicit.SetCallbackCodec(custom)
+	explicit.useSigningKey("secret")
+	if explicit.CallbackCodec() != custom {
+		t.Fatal("signing key replaced an explicit codec")
+	}
+}
@@ -11,3 +11,64 @@ func NewSimpleArticleResult(id, title, text string) *tele.ArticleResult {
 	result.SetResultID(id)
 	return result
 }
+
+// NewArticleResult creates an ArticleResult with a description shown in the
+// result list and message text sent using parseMode.
+func NewArticleResult(id, title, description, text string, parseMode tele.ParseMode) *tele.ArticleResult {
+	result := &tele.ArticleResult{
+		Title:       title,
+		Description: description,
+		Text:        text,
+	}
+	result.SetResultID(id)
+	result.SetParseMode(parseMode)
+	return result
+}
+
+// NewPhotoResult creates a PhotoResult for a JPEG at photoURL; thumbURL
+// defaults to photoURL.
+func NewPhotoResult(id, photoURL, thumbURL, caption string) *tele.PhotoResult {
+	if thumbURL == "" {
+		thumbURL = photoURL
+	}
+	result := &tele.PhotoResult{
+		URL:      photoURL,
+		ThumbURL: thumbURL,
+		Caption:  caption,
+	}
+	result.SetResultID(id)
+	return result
+}
+
+// NewCachedPhotoResult creates a PhotoResult for a photo already uploaded to Telegram.
+func NewCachedPhotoResult(id, fileID, caption string) *tele.PhotoResult {
+	result := &tele.PhotoResult{
+		Cache:   fileID,
+		Caption: caption,
+	}
+	result.SetResultID(id)
+	return result
+}
+
+// NewDocumentResult creates a DocumentResult for a PDF or ZIP at documentURL.
+func NewDocumentResult(id, title, documentURL, mime, caption string) *tele.DocumentResult {
+	result := &tele.DocumentResult{
+		Title:   title,
+		URL:     documentURL,
+		MIME:    mime,
+		Caption: caption,
+	}
+	result.SetResultID(id)
+	return result
+}
+
+// NewCachedDocumentResult creates a DocumentResult for a file already uploaded to Telegram.
+func NewCachedDocumentResult(id, title, fileID, caption string) *tele.DocumentResult {
+	result := &tele.DocumentResult{
+		Title:   title,
+		Cache:   fileID,
+		Caption: caption,
+	}
+	result.SetResultID(id)
+	return result
+}
@@ -9,3 +9,10 @@ type FallbackProvider interface {
 	UnknownDocument() tele.HandlerFunc
 	UnknownCallback() tele.HandlerFunc
 }
+
+// MediaFallbackProvider extends FallbackProvider with a handler for media
+// and other non-text messages (see telegram.MediaKind) nobody expects.
+type MediaFallbackProvider interface {
+	FallbackProvider
+	UnknownMedia(kind string) tele.HandlerFunc
+}
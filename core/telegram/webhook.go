@@ -0,0 +1,280 @@
+package telegram
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3rciful/gobot/core/logger"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// TelegramNetworks lists the subnets Telegram sends webhook requests from.
+var TelegramNetworks = []string{"149.154.160.0/20", "91.108.4.0/22"}
+
+// SecretTokenHeader carries the webhook secret token on every update request.
+const SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+const (
+	webhookMaxBody         = 1 << 20
+	webhookShutdownTimeout = 5 * time.Second
+)
+
+// Webhook receives updates over HTTPS. It is a tele.Poller that registers
+// itself with setWebhook, and an http.Handler that can be mounted on an
+// existing server when WebhookOptions.External is set.
+//
+// Every request is checked before it reaches the bot: only POST is accepted,
+// the secret token must match, and with RestrictIPs the client address must
+// belong to one of the allowed networks.
+type Webhook struct {
+	hook       *tele.Webhook
+	listen     string
+	path       string
+	certFile   string
+	keyFile    string
+	secret     string
+	networks   []netip.Prefix
+	trustProxy bool
+	external   bool
+	heartbeat  *Heartbeat
+	failed     chan error
+
+	mu   sync.RWMutex
+	dest chan<- tele.Update
+	stop <-chan struct{}
+}
+
+// NewWebhook validates opts and builds a webhook poller.
+func NewWebhook(opts WebhookOptions) (*Webhook, error) {
+	if strings.TrimSpace(opts.URL) == "" {
+		return nil, errors.New("telegram: webhook url is required")
+	}
+	public, err := url.Parse(opts.URL)
+	if err != nil {
+		return nil, fmt.Errorf("telegram: webhook url: %w", err)
+	}
+	if (opts.CertFile == "") != (opts.KeyFile == "") {
+		return nil, errors.New("telegram: webhook cert_file and key_file must be set together")
+	}
+	if opts.SelfSigned && opts.CertFile == "" {
+		return nil, errors.New("telegram: webhook self_signed requires cert_file")
+	}
+
+	w := &Webhook{
+		listen:     fmt.Sprintf("%s:%d", opts.Listen, opts.Port),
+		path:       opts.Path,
+		certFile:   opts.CertFile,
+		keyFile:    opts.KeyFile,
+		secret:     opts.SecretToken,
+		trustProxy: opts.TrustProxy,
+		external:   opts.External,
+		failed:     make(chan error, 1),
+	}
+	if w.path == "" {
+		w.path = public.Path
+	}
+	if !strings.HasPrefix(w.path, "/") {
+		w.path = "/" + w.path
+	}
+	if opts.RestrictIPs {
+		networks := opts.AllowedNetworks
+		if len(networks) == 0 {
+			networks = TelegramNetworks
+		}
+		for _, raw := range networks {
+			prefix, err := netip.ParsePrefix(strings.TrimSpace(raw))
+			if err != nil {
+				return nil, fmt.Errorf("telegram: webhook allowed network %q: %w", raw, err)
+			}
+			w.networks = append(w.networks, prefix.Masked())
+		}
+	}
+
+	w.hook = &tele.Webhook{
+		MaxConnections: opts.MaxConnections,
+		AllowedUpdates: opts.AllowedUpdates,
+		DropUpdates:    opts.DropPendingUpdates,
+		SecretToken:    opts.SecretToken,
+		Endpoint:       &tele.WebhookEndpoint{PublicURL: opts.URL},
+	}
+	if opts.SelfSigned {
+		w.hook.Endpoint.Cert = opts.CertFile
+	}
+	return w, nil
+}
+
+// Path is the local path updates are served on.
+func (w *Webhook) Path() string {
+	return w.path
+}
+
+// PublicURL is the URL registered with Telegram.
+func (w *Webhook) PublicURL() string {
+	return w.hook.Endpoint.PublicURL
+}
+
+// Listen is the address of the built-in listener; empty for external webhooks.
+func (w *Webhook) Listen() string {
+	if w.external {
+		return ""
+	}
+	return w.listen
+}
+
+// Failed delivers the error when setWebhook or the built-in listener fails;
+// the bot receives no updates afterwards, so RunTelegram stops and returns it.
+func (w *Webhook) Failed() <-chan error {
+	return w.failed
+}
+
+// fail reports err to the bot and on Failed.
+func (w *Webhook) fail(b *tele.Bot, err error) {
+	b.OnError(err, nil)
+	select {
+	case w.failed <- err:
+	default:
+	}
+}
+
+// Poll registers the webhook and, unless external, serves it until stop is closed.
+func (w *Webhook) Poll(b *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	if err := b.SetWebhook(w.hook); err != nil {
+		w.fail(b, fmt.Errorf("telegram: setWebhook: %w", err))
+		<-stop
+		return
+	}
+
+	w.mu.Lock()
+	w.dest, w.stop = dest, stop
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		w.dest, w.stop = nil, nil
+		w.mu.Unlock()
+	}()
+
+	if w.external {
+		<-stop
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(w.path, w)
+	srv := &http.Server{Addr: w.listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-stop
+		ctx, cancel := context.WithTimeout(context.Background(), webhookShutdownTimeout)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	var err error
+	if w.certFile != "" {
+		err = srv.ListenAndServeTLS(w.certFile, w.keyFile)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		w.fail(b, fmt.Errorf("telegram: webhook listener: %w", err))
+		<-stop
+	}
+}
+
+// ServeHTTP verifies the request and hands the update to the bot.
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Set("Allow", http.MethodPost)
+		w.reject(rw, r, http.StatusMethodNotAllowed, "method")
+		return
+	}
+	if !w.allowedIP(r) {
+		w.reject(rw, r, http.StatusForbidden, "ip")
+		return
+	}
+	if w.secret != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get(SecretTokenHeader)), []byte(w.secret)) != 1 {
+		w.reject(rw, r, http.StatusUnauthorized, "secret_token")
+		return
+	}
+
+	var update tele.Update
+	if err := json.NewDecoder(http.MaxBytesReader(rw, r.Body, webhookMaxBody)).Decode(&update); err != nil {
+		w.reject(rw, r, http.StatusBadRequest, "body")
+		return
+	}
+
+	w.mu.RLock()
+	dest, stop := w.dest, w.stop
+	w.mu.RUnlock()
+	if dest == nil {
+		// Not polling yet or already stopped: let Telegram retry later.
+		w.reject(rw, r, http.StatusServiceUnavailable, "not_running")
+		return
+	}
+	select {
+	case dest <- update:
+		if w.heartbeat != nil {
+			w.heartbeat.Beat()
+		}
+		rw.WriteHeader(http.StatusOK)
+	case <-stop:
+		w.reject(rw, r, http.StatusServiceUnavailable, "stopping")
+	case <-r.Context().Done():
+	}
+}
+
+func (w *Webhook) reject(rw http.ResponseWriter, r *http.Request, status int, reason string) {
+	logger.Warn(r.Context(), "tg", "webhook.reject",
+		slog.String("reason", reason),
+		slog.Int("status", status),
+		slog.String("remote", r.RemoteAddr),
+	)
+	http.Error(rw, http.StatusText(status), status)
+}
+
+func (w *Webhook) allowedIP(r *http.Request) bool {
+	if len(w.networks) == 0 {
+		return true
+	}
+	addr, ok := w.clientAddr(r)
+	if !ok {
+		return false
+	}
+	for _, prefix := range w.networks {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientAddr returns the request origin; behind a trusted proxy it is the
+// last hop recorded in X-Forwarded-For.
+func (w *Webhook) clientAddr(r *http.Request) (netip.Addr, bool) {
+	raw := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(raw); err == nil {
+		raw = host
+	}
+	if w.trustProxy {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			hops := strings.Split(fwd, ",")
+			raw = strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
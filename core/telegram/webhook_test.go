@@ -0,0 +1,100 @@
+package telegram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+func TestWebhookServeHTTP(t *testing.T) {
+	w, err := NewWebhook(WebhookOptions{
+		URL:         "https://bot.example.com/tg/hook",
+		SecretToken: "s3cret",
+		RestrictIPs: true,
+		External:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Path() != "/tg/hook" {
+		t.Fatalf("path = %q", w.Path())
+	}
+	dest := make(chan tele.Update, 1)
+	w.dest, w.stop = dest, make(chan struct{})
+
+	cases := []struct {
+		name   string
+		method string
+		remote string
+		secret string
+		want   int
+	}{
+		{"ok", http.MethodPost, "149.154.167.220:443", "s3cret", http.StatusOK},
+		{"method", http.MethodGet, "149.154.167.220:443", "s3cret", http.StatusMethodNotAllowed},
+		{"foreign ip", http.MethodPost, "203.0.113.7:443", "s3cret", http.StatusForbidden},
+		{"bad secret", http.MethodPost, "91.108.4.10:443", "nope", http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, "/tg/hook", strings.NewReader(`{"update_id":7}`))
+		req.RemoteAddr = tc.remote
+		req.Header.Set(SecretTokenHeader, tc.secret)
+		rec := httptest.NewRecorder()
+		w.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s: status %d, want %d", tc.name, rec.Code, tc.want)
+		}
+	}
+	if upd := <-dest; upd.ID != 7 {
+		t.Fatalf("update id %d", upd.ID)
+	}
+}
+
+func TestNewWebhookRejectsBadNetwork(t *testing.T) {
+	_, err := NewWebhook(WebhookOptions{URL: "https://x/y", RestrictIPs: true, AllowedNetworks: []string{"nope"}})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestWebhookReportsSetWebhookFailure(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: bad webhook"}`))
+	}))
+	defer api.Close()
+	bot, err := tele.NewBot(tele.Settings{URL: api.URL, Token: "test", Offline: true, OnError: func(error, tele.Context) {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWebhook(WebhookOptions{URL: "https://bot.example.com/hook", External: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		w.Poll(bot, make(chan tele.Update), stop)
+		close(done)
+	}()
+	select {
+	case err := <-w.Failed():
+		if !strings.Contains(err.Error(), "setWebhook") {
+			t.Fatalf("failure = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("setWebhook failure not reported")
+	}
+	close(stop)
+	<-done
+}
+
+func TestBuildPollerFallsBackOnBadWebhook(t *testing.T) {
+	p := BuildPoller(PollerOptions{RunMode: RunModeWebhook})
+	if _, ok := p.(*tele.LongPoller); !ok {
+		t.Fatalf("poller = %T, want *tele.LongPoller", p)
+	}
+}
@@ -0,0 +1,13 @@
+// Package tracing records spans for updates, handlers, outbound jobs and
+// Telegram API calls, and exports them to stdout or an OTLP/HTTP collector.
+//
+// Tracing is off until Setup installs an exporter; Start then returns a nil
+// *Span whose methods are no-ops. Span identifiers are attached to the
+// context with logger.WithTrace, so log records written with that context
+// carry trace_id and span_id automatically.
+//
+// Telegram API spans join the trace of the span in the request context.
+// Telebot sends requests without one, so its API calls are standalone
+// traces; the update trace covers them through the send span of the
+// dispatcher job, which carries the same telegram.method attribute.
+package tracing
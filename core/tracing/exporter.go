@@ -0,0 +1,213 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StdoutExporter writes one JSON object per span, for local debugging.
+type StdoutExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewStdoutExporter writes spans to w.
+func NewStdoutExporter(w io.Writer) *StdoutExporter {
+	return &StdoutExporter{w: w}
+}
+
+type stdoutSpan struct {
+	TraceID    string         `json:"trace_id"`
+	SpanID     string         `json:"span_id"`
+	ParentID   string         `json:"parent_id,omitempty"`
+	Name       string         `json:"name"`
+	Start      time.Time      `json:"start"`
+	DurationMS float64        `json:"duration_ms"`
+	Attrs      map[string]any `json:"attrs,omitempty"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// ExportSpans implements Exporter.
+func (e *StdoutExporter) ExportSpans(_ context.Context, spans []SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	enc := json.NewEncoder(e.w)
+	for _, s := range spans {
+		out := stdoutSpan{
+			TraceID: s.TraceID, SpanID: s.SpanID, ParentID: s.ParentID, Name: s.Name,
+			Start: s.Start, DurationMS: float64(s.End.Sub(s.Start).Microseconds()) / 1000, Error: s.Err,
+		}
+		if len(s.Attrs) > 0 {
+			out.Attrs = make(map[string]any, len(s.Attrs))
+			for _, a := range s.Attrs {
+				out.Attrs[a.Key] = a.Value
+			}
+		}
+		if err := enc.Encode(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown implements Exporter.
+func (e *StdoutExporter) Shutdown(context.Context) error { return nil }
+
+// OTLPOptions configures an OTLP/HTTP exporter.
+type OTLPOptions struct {
+	// Endpoint is the collector base URL (default http://localhost:4318);
+	// spans are posted to <Endpoint>/v1/traces.
+	Endpoint string
+	// Headers are added to every request, for example for authentication.
+	Headers     map[string]string
+	ServiceName string
+	// Timeout bounds each export request (default 10s).
+	Timeout time.Duration
+}
+
+// OTLPExporter posts spans to an OpenTelemetry collector using OTLP/HTTP with JSON encoding.
+type OTLPExporter struct {
+	url     string
+	headers map[string]string
+	service string
+	client  *http.Client
+}
+
+// NewOTLPExporter builds an exporter; it uses its own HTTP client so export
+// calls are never traced themselves.
+func NewOTLPExporter(opts OTLPOptions) *OTLPExporter {
+	endpoint := strings.TrimRight(opts.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = "http://localhost:4318"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.ServiceName == "" {
+		opts.ServiceName = "gobot"
+	}
+	return &OTLPExporter{
+		url:     endpoint + "/v1/traces",
+		headers: opts.Headers,
+		service: opts.ServiceName,
+		client:  &http.Client{Timeout: opts.Timeout},
+	}
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpAttr struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []otlpAttr `json:"attributes,omitempty"`
+	Status            otlpStatus `json:"status"`
+}
+
+// ExportSpans implements Exporter.
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []SpanData) error {
+	out := make([]otlpSpan, len(spans))
+	for i, s := range spans {
+		out[i] = otlpSpan{
+			TraceID:           s.TraceID,
+			SpanID:            s.SpanID,
+			ParentSpanID:      s.ParentID,
+			Name:              s.Name,
+			Kind:              int(s.Kind),
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+			Attributes:        otlpAttrs(s.Attrs),
+			Status:            otlpStatus{Code: 1},
+		}
+		if s.Err != "" {
+			out[i].Status = otlpStatus{Code: 2, Message: s.Err}
+		}
+	}
+	body := map[string]any{
+		"resourceSpans": []any{map[string]any{
+			"resource":   map[string]any{"attributes": otlpAttrs([]Attr{String("service.name", e.service)})},
+			"scopeSpans": []any{map[string]any{"scope": map[string]string{"name": "gobot"}, "spans": out}},
+		}},
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("otlp export: status %s", resp.Status)
+	}
+	return nil
+}
+
+// Shutdown implements Exporter.
+func (e *OTLPExporter) Shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+func otlpAttrs(attrs []Attr) []otlpAttr {
+	out := make([]otlpAttr, 0, len(attrs))
+	for _, a := range attrs {
+		var v otlpValue
+		switch val := a.Value.(type) {
+		case string:
+			v.StringValue = &val
+		case bool:
+			v.BoolValue = &val
+		case int:
+			s := strconv.Itoa(val)
+			v.IntValue = &s
+		case int64:
+			s := strconv.FormatInt(val, 10)
+			v.IntValue = &s
+		case float64:
+			v.DoubleValue = &val
+		default:
+			s := fmt.Sprint(val)
+			v.StringValue = &s
+		}
+		out = append(out, otlpAttr{Key: a.Key, Value: v})
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+package tracing
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/m3rciful/gobot/core/logger"
+)
+
+const (
+	defaultBatchSize     = 256
+	defaultQueueSize     = 2048
+	defaultFlushInterval = 5 * time.Second
+)
+
+// Exporter ships finished spans.
+type Exporter interface {
+	ExportSpans(ctx context.Context, spans []SpanData) error
+	Shutdown(ctx context.Context) error
+}
+
+// Options configures Setup.
+type Options struct {
+	Exporter Exporter
+	// SampleRatio is the fraction of traces recorded, in (0, 1]; 0 -> 1.
+	SampleRatio float64
+	// BatchSize (default 256) and FlushInterval (default 5s) control export batching.
+	BatchSize     int
+	FlushInterval time.Duration
+	// QueueSize bounds spans waiting for export (default 2048); extra spans are dropped.
+	QueueSize int
+}
+
+type provider struct {
+	exp      Exporter
+	ratio    float64
+	batch    int
+	interval time.Duration
+
+	// mu guards closing spans against concurrent enqueues.
+	mu      sync.RWMutex
+	closed  bool
+	spans   chan SpanData
+	dropped atomic.Uint64
+	once    sync.Once
+	done    chan struct{}
+}
+
+var global atomic.Pointer[provider]
+
+func current() *provider {
+	return global.Load()
+}
+
+// Setup enables tracing with opts.Exporter and returns a shutdown function
+// that stops recording, flushes queued spans and shuts the exporter down.
+// A previous setup is replaced without being flushed.
+func Setup(opts Options) func(ctx context.Context) error {
+	if opts.Exporter == nil {
+		return func(context.Context) error { return nil }
+	}
+	if opts.SampleRatio <= 0 || opts.SampleRatio > 1 {
+		opts.SampleRatio = 1
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultFlushInterval
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultQueueSize
+	}
+	p := &provider{
+		exp:      opts.Exporter,
+		ratio:    opts.SampleRatio,
+		batch:    opts.BatchSize,
+		interval: opts.FlushInterval,
+		spans:    make(chan SpanData, opts.QueueSize),
+		done:     make(chan struct{}),
+	}
+	global.Store(p)
+	go p.run()
+	return p.shutdown
+}
+
+func (p *provider) sample() bool {
+	return p.ratio >= 1 || rand.Float64() < p.ratio
+}
+
+func (p *provider) enqueue(data SpanData) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		p.dropped.Add(1)
+		return
+	}
+	select {
+	case p.spans <- data:
+	default:
+		p.dropped.Add(1)
+	}
+}
+
+func (p *provider) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	batch := make([]SpanData, 0, p.batch)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), p.interval)
+		if err := p.exp.ExportSpans(ctx, batch); err != nil {
+			logger.Warn(ctx, "tracing", "export.fail",
+				slog.Int("spans", len(batch)),
+				slog.String("err", err.Error()),
+			)
+		}
+		cancel()
+		batch = make([]SpanData, 0, p.batch)
+	}
+	for {
+		select {
+		case data, ok := <-p.spans:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, data)
+			if len(batch) >= p.batch {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (p *provider) shutdown(ctx context.Context) error {
+	var err error
+	p.once.Do(func() {
+		global.CompareAndSwap(p, nil)
+		p.mu.Lock()
+		p.closed = true
+		close(p.spans)
+		p.mu.Unlock()
+		select {
+		case <-p.done:
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		}
+		if n := p.dropped.Load(); n > 0 {
+			logger.Warn(ctx, "tracing", "spans.dropped", slog.Uint64("count", n))
+		}
+		err = p.exp.Shutdown(ctx)
+	})
+	return err
+}
@@ -0,0 +1,153 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/m3rciful/gobot/core/logger"
+)
+
+// Kind describes the role of a span, mirroring the OTLP span kinds.
+type Kind int
+
+// Span kinds.
+const (
+	KindInternal Kind = 1
+	KindServer   Kind = 2
+	KindClient   Kind = 3
+	KindProducer Kind = 4
+	KindConsumer Kind = 5
+)
+
+// Attr is a span attribute; Value is a string, bool, integer or float.
+type Attr struct {
+	Key   string
+	Value any
+}
+
+// String returns a string attribute.
+func String(key, value string) Attr { return Attr{key, value} }
+
+// Int64 returns an integer attribute.
+func Int64(key string, value int64) Attr { return Attr{key, value} }
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attr { return Attr{key, value} }
+
+// SpanData is a finished span as handed to exporters.
+type SpanData struct {
+	TraceID  string
+	SpanID   string
+	ParentID string
+	Name     string
+	Kind     Kind
+	Start    time.Time
+	End      time.Time
+	Attrs    []Attr
+	// Err is the error message when the span failed.
+	Err string
+}
+
+// Span is an in-flight operation. A nil *Span is valid and ignores every call.
+type Span struct {
+	p *provider
+
+	mu    sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+type spanKey struct{}
+
+// SpanFrom returns the span stored in ctx, or nil.
+func SpanFrom(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// Start begins a span as a child of the span in ctx. The returned context
+// carries the span and its identifiers for logging. When tracing is off or
+// the trace is not sampled, ctx is returned unchanged with a nil span.
+func Start(ctx context.Context, name string, kind Kind, attrs ...Attr) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	p := current()
+	if p == nil {
+		return ctx, nil
+	}
+	parent := SpanFrom(ctx)
+	data := SpanData{Name: name, Kind: kind, Start: time.Now(), Attrs: attrs, SpanID: newID(8)}
+	if parent != nil {
+		data.TraceID, data.ParentID = parent.data.TraceID, parent.data.SpanID
+	} else {
+		if !p.sample() {
+			return ctx, nil
+		}
+		data.TraceID = newID(16)
+	}
+	span := &Span{p: p, data: data}
+	return ContextWithSpan(ctx, span), span
+}
+
+// ContextWithSpan returns ctx carrying span and its identifiers for logging,
+// so work done under a rebuilt context stays in the same trace. A nil span
+// leaves ctx unchanged.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, spanKey{}, span)
+	return logger.WithTrace(ctx, span.data.TraceID, span.data.SpanID)
+}
+
+// SetAttrs adds attributes to the span.
+func (s *Span) SetAttrs(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.data.Attrs = append(s.data.Attrs, attrs...)
+	s.mu.Unlock()
+}
+
+// End finishes the span, marking it failed when err is not nil, and queues
+// it for export. Only the first call has an effect.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	if err != nil {
+		s.data.Err = err.Error()
+	}
+	data := s.data
+	s.mu.Unlock()
+	s.p.enqueue(data)
+}
+
+// TraceID returns the hex trace identifier, or "" for a nil span.
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+	return s.data.TraceID
+}
+
+func newID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/m3rciful/gobot/core/logger"
+)
+
+func TestStartExportsParentChild(t *testing.T) {
+	var buf bytes.Buffer
+	shutdown := Setup(Options{Exporter: NewStdoutExporter(&buf)})
+
+	ctx, root := Start(context.Background(), "update.message", KindServer, Int64("update_id", 7))
+	if got := logger.TraceIDFrom(ctx); got != root.TraceID() || got == "" {
+		t.Fatalf("trace id in ctx = %q, want %q", got, root.TraceID())
+	}
+	childCtx, child := Start(ctx, "handler.start", KindInternal)
+	if logger.TraceIDFrom(childCtx) != root.TraceID() {
+		t.Fatal("child span left the trace")
+	}
+	child.End(errors.New("boom"))
+	root.End(nil)
+	root.End(errors.New("ignored"))
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if _, span := Start(context.Background(), "after", KindInternal); span != nil {
+		t.Fatal("span started after shutdown")
+	}
+
+	var spans []stdoutSpan
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var s stdoutSpan
+		if err := dec.Decode(&s); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		spans = append(spans, s)
+	}
+	if len(spans) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(spans))
+	}
+	c, r := spans[0], spans[1]
+	if c.Name != "handler.start" || c.ParentID != r.SpanID || c.TraceID != r.TraceID {
+		t.Fatalf("unexpected child %+v for root %+v", c, r)
+	}
+	if c.Error != "boom" || r.Error != "" {
+		t.Fatalf("errors = %q, %q", c.Error, r.Error)
+	}
+	if r.Attrs["update_id"] != float64(7) {
+		t.Fatalf("root attrs = %v", r.Attrs)
+	}
+}
+
+func TestNilSpan(t *testing.T) {
+	ctx, span := Start(context.Background(), "off", KindInternal)
+	if span != nil || SpanFrom(ctx) != nil {
+		t.Fatal("expected no span while tracing is off")
+	}
+	span.SetAttrs(String("k", "v"))
+	span.End(nil)
+}